@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/geekjourneyx/jina-cli/cli/pkg/output"
+)
+
+// forEachConcurrent 以最多 concurrency 个 worker 并发处理 [0, n) 中的任务
+//
+// ctx 取消后不再分派新任务，已分派的任务由 fn 自行处理取消。
+// 所有已分派的任务结束后返回。
+func forEachConcurrent(ctx context.Context, n, concurrency int, fn func(i int)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > n {
+		concurrency = n
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+
+dispatch:
+	for i := 0; i < n; i++ {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case jobs <- i:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// batchCollector 收集批量处理的结果，可被多个 worker 并发调用
+//
+// 输出支持流式（NDJSON）时，结果完成后立即写出，不在内存中保留；
+// 否则按输入顺序缓存，结束时一次性输出。
+type batchCollector struct {
+	mu           sync.Mutex
+	out          output.Output
+	stream       output.StreamOutput
+	results      []map[string]interface{}
+	total        int
+	completed    int
+	failed       int
+	showProgress bool
+	summary      map[string]interface{} // 流式输出时追加到汇总记录的字段
+}
+
+// newBatchCollector 创建批量结果收集器
+func newBatchCollector(out output.Output, total int, showProgress bool) *batchCollector {
+	c := &batchCollector{
+		out:          out,
+		total:        total,
+		showProgress: showProgress,
+	}
+	if stream, ok := out.(output.StreamOutput); ok {
+		c.stream = stream
+	} else {
+		c.results = make([]map[string]interface{}, total)
+	}
+	return c
+}
+
+// add 记录第 i 个任务的结果，label 用于进度显示，为空时不显示进度
+func (c *batchCollector) add(i int, label string, result map[string]interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.completed++
+	if _, failed := result["error"]; failed {
+		c.failed++
+	}
+	if c.stream != nil {
+		if err := c.stream.PrintItem(i, result); err != nil {
+			fmt.Fprintf(os.Stderr, "警告: %v\n", err)
+		}
+	} else {
+		c.results[i] = result
+	}
+
+	if c.showProgress && label != "" {
+		fmt.Fprintf(os.Stderr, "已完成 [%d/%d]: %s\n", c.completed, c.total, label)
+	}
+}
+
+// finish 输出结果（流式输出时输出汇总记录），被中断时以中断退出码退出
+func (c *batchCollector) finish(interrupted bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stream != nil {
+		summary := map[string]interface{}{
+			"total":     c.total,
+			"completed": c.completed,
+			"succeeded": c.completed - c.failed,
+			"failed":    c.failed,
+		}
+		for k, v := range c.summary {
+			summary[k] = v
+		}
+		if interrupted {
+			summary["interrupted"] = true
+		}
+		_ = c.stream.PrintSummary(summary)
+	} else {
+		// 按输入顺序输出，被中断时跳过未完成的任务
+		data := make([]interface{}, 0, c.completed)
+		for _, result := range c.results {
+			if result != nil {
+				data = append(data, result)
+			}
+		}
+		_ = c.out.Print(data)
+	}
+
+	if interrupted {
+		exitInterrupted(c.out, c.completed, c.total)
+	}
+}
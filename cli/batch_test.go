@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/geekjourneyx/jina-cli/cli/pkg/output"
+)
+
+// recordOutput 记录 Print 数据的 output.Output
+type recordOutput struct {
+	printed []interface{}
+}
+
+func (r *recordOutput) Print(data interface{}) error {
+	r.printed = append(r.printed, data)
+	return nil
+}
+
+func (r *recordOutput) Error(err error) error {
+	return err
+}
+
+// streamRecorder 记录逐条输出和汇总记录的 output.StreamOutput
+type streamRecorder struct {
+	recordOutput
+	indexes []int
+	summary map[string]interface{}
+}
+
+func (s *streamRecorder) PrintItem(index int, data interface{}) error {
+	s.indexes = append(s.indexes, index)
+	return nil
+}
+
+func (s *streamRecorder) PrintSummary(data interface{}) error {
+	s.summary = data.(map[string]interface{})
+	return nil
+}
+
+func TestForEachConcurrent(t *testing.T) {
+	tests := []struct {
+		name        string
+		n           int
+		concurrency int
+	}{
+		{"sequential", 5, 1},
+		{"parallel", 20, 4},
+		{"more workers than tasks", 3, 10},
+		{"zero concurrency", 4, 0},
+		{"no tasks", 0, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			seen := make(map[int]int)
+			var running, peak int32
+			forEachConcurrent(context.Background(), tt.n, tt.concurrency, func(i int) {
+				cur := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				for {
+					old := atomic.LoadInt32(&peak)
+					if cur <= old || atomic.CompareAndSwapInt32(&peak, old, cur) {
+						break
+					}
+				}
+				mu.Lock()
+				seen[i]++
+				mu.Unlock()
+			})
+
+			if len(seen) != tt.n {
+				t.Fatalf("processed %d tasks, want %d", len(seen), tt.n)
+			}
+			for i, count := range seen {
+				if count != 1 {
+					t.Errorf("task %d processed %d times", i, count)
+				}
+			}
+			limit := int32(max(tt.concurrency, 1))
+			if peak > limit {
+				t.Errorf("peak concurrency = %d, want <= %d", peak, limit)
+			}
+		})
+	}
+}
+
+func TestForEachConcurrent_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls int32
+	forEachConcurrent(ctx, 10, 2, func(i int) { atomic.AddInt32(&calls, 1) })
+	if calls != 0 {
+		t.Errorf("canceled run processed %d tasks, want 0", calls)
+	}
+}
+
+func TestBatchCollector(t *testing.T) {
+	// 任务按 2、0、1 的顺序完成，其中 1 失败
+	order := []int{2, 0, 1}
+	results := []map[string]interface{}{
+		{"url": "https://a.com"},
+		{"url": "https://b.com", "error": "HTTP 错误: 422", "code": "TARGET_UNREACHABLE"},
+		{"url": "https://c.com"},
+	}
+
+	tests := []struct {
+		name   string
+		stream bool
+	}{
+		{"ordered", false},
+		{"stream", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out output.Output
+			rec := &streamRecorder{}
+			if tt.stream {
+				out = rec
+			} else {
+				out = &rec.recordOutput
+			}
+
+			c := newBatchCollector(out, len(results), false)
+			c.summary = map[string]interface{}{"feed": "Example"}
+			for _, i := range order {
+				c.add(i, results[i]["url"].(string), results[i])
+			}
+			c.finish(false)
+
+			if !tt.stream {
+				// 结束时按输入顺序一次性输出
+				if len(rec.printed) != 1 {
+					t.Fatalf("Print called %d times, want 1", len(rec.printed))
+				}
+				data := rec.printed[0].([]interface{})
+				if len(data) != len(results) {
+					t.Fatalf("printed %d results, want %d", len(data), len(results))
+				}
+				for i, item := range data {
+					if got := item.(map[string]interface{})["url"]; got != results[i]["url"] {
+						t.Errorf("result %d url = %v, want %v", i, got, results[i]["url"])
+					}
+				}
+				return
+			}
+
+			// 按完成顺序逐条输出，最后输出汇总
+			if len(rec.printed) != 0 {
+				t.Errorf("Print called %d times in stream mode", len(rec.printed))
+			}
+			for j, index := range rec.indexes {
+				if index != order[j] {
+					t.Errorf("item %d index = %d, want %d", j, index, order[j])
+				}
+			}
+			want := map[string]interface{}{"total": 3, "completed": 3, "succeeded": 2, "failed": 1, "feed": "Example"}
+			for k, v := range want {
+				if rec.summary[k] != v {
+					t.Errorf("summary[%s] = %v, want %v", k, rec.summary[k], v)
+				}
+			}
+			if _, ok := rec.summary["interrupted"]; ok {
+				t.Error("summary should not be marked interrupted")
+			}
+		})
+	}
+}
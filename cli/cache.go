@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/geekjourneyx/jina-cli/cli/pkg/api"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/cache"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/config"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// CacheCmd cache 命令
+var CacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the local response cache",
+	Long: `Manage the local response cache stored in ~/.jina-reader/cache.
+
+Responses are cached only when cache_tolerance (seconds) is set to a positive value in the
+config. The same value is sent to the API as the server-side cache tolerance and is used as the
+time-to-live of local entries, so one setting controls how stale a response may be on both sides.
+Use --no-cache on read/search to bypass the cache.`,
+}
+
+func init() {
+	// 添加子命令
+	CacheCmd.AddCommand(cacheStatsCmd)
+	CacheCmd.AddCommand(cacheLsCmd)
+	CacheCmd.AddCommand(cachePruneCmd)
+	CacheCmd.AddCommand(cacheClearCmd)
+
+	cachePruneCmd.Flags().StringVar(&flagCachePruneOlderThan, "older-than", "", "Remove entries older than this age, e.g. 12h or 7d (default: cache_tolerance)")
+}
+
+var flagCachePruneOlderThan string
+
+// cacheStatsCmd 显示缓存统计
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cache statistics",
+	Args:  cobra.NoArgs,
+	Run:   runCacheStats,
+}
+
+func runCacheStats(cmd *cobra.Command, args []string) {
+	stats, err := openCache().Stats()
+	if err != nil {
+		output.Error(api.NewError(api.CodeInternal, err))
+	}
+	output.Success(stats)
+}
+
+// cacheLsCmd 列出缓存条目
+var cacheLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List cached responses",
+	Args:  cobra.NoArgs,
+	Run:   runCacheLs,
+}
+
+func runCacheLs(cmd *cobra.Command, args []string) {
+	c := openCache()
+	entries, err := c.List()
+	if err != nil {
+		output.Error(api.NewError(api.CodeInternal, err))
+	}
+
+	now := time.Now()
+	data := make([]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		data = append(data, map[string]interface{}{
+			"key":     entry.Key,
+			"url":     entry.URL,
+			"created": entry.Created,
+			"bytes":   entry.Size,
+			"expired": entry.Expired(c.TTL(), now),
+		})
+	}
+	output.Success(data)
+}
+
+// cachePruneCmd 删除过期条目
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove expired cache entries",
+	Long: `Remove cache entries older than --older-than, or older than cache_tolerance when the flag
+is not given. Fails when neither is positive, so that an unset TTL never deletes the whole cache
+(use 'jina cache clear' for that).`,
+	Example: `  jina cache prune
+  jina cache prune --older-than 7d`,
+	Args: cobra.NoArgs,
+	Run:  runCachePrune,
+}
+
+func runCachePrune(cmd *cobra.Command, args []string) {
+	// 未指定 --older-than 时使用 cache_tolerance，两者都不大于 0 时拒绝清理
+	maxAge := cacheTTL()
+	if flagCachePruneOlderThan != "" {
+		age, err := parseAge(flagCachePruneOlderThan)
+		if err != nil {
+			output.Error(api.NewError(api.CodeInvalidInput, fmt.Errorf("无效的 --older-than: %w", err)))
+		}
+		maxAge = age
+	}
+	if maxAge <= 0 {
+		output.Error(api.NewError(api.CodeInvalidInput, fmt.Errorf("未设置缓存有效期：请指定大于 0 的 --older-than，或设置 cache_tolerance")))
+	}
+
+	removed, freed, err := openCache().Prune(maxAge)
+	if err != nil {
+		output.Error(api.NewError(api.CodeInternal, err))
+	}
+	output.Success(map[string]interface{}{
+		"removed": removed,
+		"bytes":   freed,
+	})
+}
+
+// cacheClearCmd 删除所有条目
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all cache entries",
+	Args:  cobra.NoArgs,
+	Run:   runCacheClear,
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) {
+	removed, freed, err := openCache().Clear()
+	if err != nil {
+		output.Error(api.NewError(api.CodeInternal, err))
+	}
+	output.Success(map[string]interface{}{
+		"removed": removed,
+		"bytes":   freed,
+	})
+}
+
+// openCache 打开配置目录下的本地缓存，有效期取自 cache_tolerance
+func openCache() *cache.Cache {
+	return cache.New(filepath.Join(config.GetConfigDir(), cache.DirName), cacheTTL())
+}
+
+// cacheTTL 解析 cache_tolerance（秒），未设置或无效时返回 0
+func cacheTTL() time.Duration {
+	seconds, err := strconv.Atoi(cfg.CacheTolerance)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -3,7 +3,10 @@ package main
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
+	"github.com/geekjourneyx/jina-cli/cli/pkg/api"
 	"github.com/geekjourneyx/jina-cli/cli/pkg/config"
 	"github.com/geekjourneyx/jina-cli/cli/pkg/output"
 	"github.com/spf13/cobra"
@@ -31,7 +34,8 @@ var configSetCmd = &cobra.Command{
 	Long:  `Set a configuration value. The value will be saved to the config file.`,
 	Example: `  jina config set api-base "https://r.jina.ai/"
   jina config set timeout 60
-  jina config set with-generated-alt true`,
+  jina config set with-generated-alt true
+  jina config set header.X-Locale en-US`,
 	Args: cobra.ExactArgs(2),
 	Run:  runConfigSet,
 }
@@ -41,7 +45,7 @@ func runConfigSet(cmd *cobra.Command, args []string) {
 	value := args[1]
 
 	if err := config.Set(key, value); err != nil {
-		output.Error(err)
+		output.Error(api.NewError(api.CodeInvalidInput, err))
 	}
 
 	output.Success(map[string]interface{}{
@@ -66,7 +70,7 @@ func runConfigGet(cmd *cobra.Command, args []string) {
 
 	value, err := config.Get(key)
 	if err != nil {
-		output.Error(err)
+		output.Error(api.NewError(api.CodeInvalidInput, err))
 	}
 
 	if value == "" {
@@ -88,7 +92,7 @@ var configListCmd = &cobra.Command{
 func runConfigList(cmd *cobra.Command, args []string) {
 	cfgList, err := config.List()
 	if err != nil {
-		output.Error(err)
+		output.Error(api.NewError(api.CodeInternal, err))
 	}
 
 	// 以表格形式输出
@@ -103,6 +107,15 @@ func runConfigList(cmd *cobra.Command, args []string) {
 		"with_generated_alt",
 		"proxy_url",
 		"cache_tolerance",
+		"max_retries",
+		"retry_delay",
+		"concurrency",
+		"server_timeout",
+		"token_budget",
+		"remove_selector",
+		"retain_images",
+		"with_iframe",
+		"with_shadow_dom",
 		"api_key",
 	}
 
@@ -111,6 +124,18 @@ func runConfigList(cmd *cobra.Command, args []string) {
 			fmt.Printf("%-25s : %s\n", key, value)
 		}
 	}
+
+	// 默认请求头
+	var headerKeys []string
+	for key := range cfgList {
+		if strings.HasPrefix(key, config.HeaderKeyPrefix) {
+			headerKeys = append(headerKeys, key)
+		}
+	}
+	sort.Strings(headerKeys)
+	for _, key := range headerKeys {
+		fmt.Printf("%-25s : %s\n", key, cfgList[key])
+	}
 	fmt.Println("========================================")
 	fmt.Printf("配置文件路径: %s\n", config.GetConfigPath())
 }
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/geekjourneyx/jina-cli/cli/pkg/api"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/crawl"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/httpheader"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// CrawlCmd crawl 命令
+var CrawlCmd = &cobra.Command{
+	Use:   "crawl",
+	Short: "Crawl a site by following links from a start page",
+	Long: `Read a start page, follow its links breadth-first and read every page found, staying on the
+same host and under a path prefix (default: the directory of the start page).
+
+URLs are deduplicated after normalization, robots.txt is honored, and requests to the same host
+are spaced by --delay (or the site's Crawl-delay if longer).`,
+	Example: `  jina crawl --url "https://docs.example.com" --depth 2 --max-pages 200
+  jina crawl -u "https://go.dev/doc/" --path-prefix /doc/ --output ndjson
+  jina crawl -u "https://example.com/guide/" --output-dir ./guide --delay 2000
+  jina crawl -u "https://example.com/blog/" --depth 1 --target-selector article`,
+	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return validateCrawlFlags()
+	},
+	Run: runCrawl,
+}
+
+var (
+	flagCrawlURL          string
+	flagCrawlDepth        int
+	flagCrawlMaxPages     int
+	flagCrawlPathPrefix   string
+	flagCrawlDelay        int
+	flagCrawlIgnoreRobots bool
+	flagCrawlFormat       string
+	flagCrawlTimeout      int
+	flagCrawlOutputFile   string
+	flagCrawlMaxRetries   int
+	flagCrawlRetryDelay   int
+	flagCrawlHeaders      []string
+	flagCrawlConcurrency  int
+	flagCrawlOutputDir    string
+	flagCrawlReadOptions  readOptions
+)
+
+func init() {
+	CrawlCmd.Flags().StringVarP(&flagCrawlURL, "url", "u", "", "Start URL (required)")
+	CrawlCmd.Flags().IntVarP(&flagCrawlDepth, "depth", "d", 2, "Max link depth to follow from the start page (0 reads only the start page)")
+	CrawlCmd.Flags().IntVar(&flagCrawlMaxPages, "max-pages", 100, "Max number of pages to read")
+	CrawlCmd.Flags().StringVar(&flagCrawlPathPrefix, "path-prefix", "", "Only follow links under this path (default: directory of the start URL)")
+	CrawlCmd.Flags().IntVar(&flagCrawlDelay, "delay", 500, "Min delay between requests to the same host in milliseconds")
+	CrawlCmd.Flags().BoolVar(&flagCrawlIgnoreRobots, "ignore-robots", false, "Do not fetch or honor robots.txt")
+	CrawlCmd.Flags().StringVarP(&flagCrawlFormat, "format", "F", "", "Response format: markdown, html, text (default: markdown)")
+	CrawlCmd.Flags().IntVarP(&flagCrawlTimeout, "timeout", "t", 0, "Request timeout in seconds")
+	CrawlCmd.Flags().StringVarP(&flagCrawlOutputFile, "output-file", "O", "", "Write output to file instead of stdout")
+	CrawlCmd.Flags().IntVar(&flagCrawlMaxRetries, "max-retries", 0, "Max retries for transient failures (default: config max_retries)")
+	CrawlCmd.Flags().IntVar(&flagCrawlRetryDelay, "retry-delay", 0, "Initial retry delay in milliseconds (default: config retry_delay)")
+	CrawlCmd.Flags().StringArrayVarP(&flagCrawlHeaders, "header", "H", nil, "Extra request header 'Name: value' (repeatable, added to config [headers])")
+	CrawlCmd.Flags().IntVarP(&flagCrawlConcurrency, "concurrency", "c", 0, "Number of pages to read in parallel (default: config concurrency)")
+	CrawlCmd.Flags().StringVar(&flagCrawlOutputDir, "output-dir", "", "Write each page to its own file in this directory plus a manifest.json")
+	addReadFlags(CrawlCmd, &flagCrawlReadOptions)
+}
+
+func validateCrawlFlags() error {
+	if flagCrawlURL == "" {
+		return fmt.Errorf("必须提供 --url 参数")
+	}
+	if _, err := crawl.NewScope(flagCrawlURL, flagCrawlPathPrefix); err != nil {
+		return err
+	}
+	if flagCrawlDepth < 0 {
+		return fmt.Errorf("--depth 不能为负数")
+	}
+	if flagCrawlMaxPages < 1 {
+		return fmt.Errorf("--max-pages 必须大于 0")
+	}
+	if flagCrawlDelay < 0 || flagCrawlConcurrency < 0 {
+		return fmt.Errorf("--delay 和 --concurrency 不能为负数")
+	}
+	if flagCrawlMaxRetries < 0 || flagCrawlRetryDelay < 0 {
+		return fmt.Errorf("--max-retries 和 --retry-delay 不能为负数")
+	}
+	if err := flagCrawlReadOptions.validate(); err != nil {
+		return err
+	}
+	if slices.Contains(api.ScreenshotFormats, flagCrawlFormat) {
+		return fmt.Errorf("crawl 不支持 --format %s（截图中没有链接）", flagCrawlFormat)
+	}
+	for _, h := range flagCrawlHeaders {
+		if _, _, err := httpheader.Parse(h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runCrawl(cmd *cobra.Command, args []string) {
+	// 获取输出格式
+	outputFormat := getReadOutputFormat(cmd)
+
+	// 创建 API 客户端
+	client := newReadClient(cmd, flagCrawlTimeout, flagCrawlMaxRetries, flagCrawlRetryDelay, flagCrawlHeaders, &flagCrawlReadOptions)
+
+	// 获取输出处理器
+	out, err := output.GetOutput(output.OutputFormat(outputFormat), flagCrawlOutputFile)
+	if err != nil {
+		output.Error(api.NewError(api.CodeInternal, err))
+	}
+	defer closeOutput(out)
+
+	processCrawl(cmd.Context(), client, responseFormatOf(flagCrawlFormat), outputFormat == "markdown", out)
+}
+
+// processCrawl 从 --url 开始抓取，每读取一个页面立即交给批量结果收集器
+//
+// 结果按抓取顺序输出并带有 depth 字段；读取失败的页面记录错误，不影响其他页面。
+// 页面总是带链接列表读取以发现新页面，未指定 --with-links-summary 时不输出 links 字段。
+// 被中断时输出已完成的结果并以中断退出码退出。
+func processCrawl(ctx context.Context, client *api.Client, responseFormat string, showProgress bool, out output.Output) {
+	scope, _ := crawl.NewScope(flagCrawlURL, flagCrawlPathPrefix)
+
+	opts := crawl.Options{
+		Scope:       scope,
+		MaxDepth:    flagCrawlDepth,
+		MaxPages:    flagCrawlMaxPages,
+		Concurrency: batchConcurrency(flagCrawlConcurrency),
+		Delay:       time.Duration(flagCrawlDelay) * time.Millisecond,
+	}
+	if !flagCrawlIgnoreRobots {
+		opts.Robots = client.DownloadContext
+	}
+
+	// 每个结果写入输出目录
+	var dir *output.DirWriter
+	if flagCrawlOutputDir != "" {
+		var err error
+		dir, err = output.NewDirWriter(flagCrawlOutputDir)
+		if err != nil {
+			_ = out.Error(api.NewError(api.CodeInternal, err))
+			return
+		}
+	}
+
+	if showProgress {
+		fmt.Fprintf(os.Stderr, "正在抓取 %s（范围 %s%s，深度 %d，最多 %d 个页面）...\n",
+			flagCrawlURL, scope.Host, scope.PathPrefix, flagCrawlDepth, flagCrawlMaxPages)
+	}
+
+	// 页面总数在抓取结束前未知，先按上限分配，结束时更新为实际数量
+	collector := newBatchCollector(out, flagCrawlMaxPages, showProgress)
+	stats, err := crawl.Crawl(ctx, flagCrawlURL, opts, func(ctx context.Context, index int, page crawl.Page) []string {
+		req := buildReadRequest(page.URL, responseFormat, &flagCrawlReadOptions)
+		req.WithLinksSummary = true
+		result := readBatchURL(ctx, client, req)
+		if result == nil {
+			return nil
+		}
+
+		links, _ := result["links"].([]api.Link)
+		found := make([]string, len(links))
+		for i, link := range links {
+			found[i] = link.URL
+		}
+		if !flagCrawlReadOptions.linksSummary {
+			delete(result, "links")
+		}
+
+		if dir != nil {
+			result = saveResultToDir(ctx, client, dir, page.URL, result, responseFormat)
+			if result == nil {
+				return nil
+			}
+		}
+		result["depth"] = page.Depth
+		collector.add(index, page.URL, result)
+		return found
+	})
+	if err != nil {
+		_ = out.Error(api.NewError(api.CodeInvalidInput, err))
+		return
+	}
+
+	collector.total = stats.Visited
+	collector.summary = map[string]interface{}{
+		"disallowed":   stats.Disallowed,
+		"out_of_scope": stats.OutOfScope,
+	}
+	interrupted := ctx.Err() != nil
+	// 中断时 finish 会直接退出，因此先写入清单
+	if dir != nil {
+		if err := dir.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "警告: %v\n", err)
+		}
+	}
+	collector.finish(interrupted)
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/geekjourneyx/jina-cli/cli/pkg/api"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/feed"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/httpheader"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/output"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/state"
+	"github.com/spf13/cobra"
+)
+
+// FeedCmd feed 命令
+var FeedCmd = &cobra.Command{
+	Use:   "feed",
+	Short: "List or read the entries of an RSS or Atom feed",
+	Long: `Fetch an RSS 2.0 or Atom feed and list its entries (title, link, date), or read each entry's
+link like 'jina read' with --read.
+
+Use --since to skip older entries, or --state to remember seen entries so that each run only
+returns entries that are new since the previous run.`,
+	Example: `  jina feed --url "https://go.dev/blog/feed.atom"
+  jina feed -u "https://example.com/feed.xml" --since 7d --output markdown
+  jina feed -u "https://example.com/feed.xml" --read --limit 5 --target-selector article
+  jina feed -u "https://example.com/feed.xml" --read --state feed.state --output ndjson`,
+	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return validateFeedFlags()
+	},
+	Run: runFeed,
+}
+
+var (
+	flagFeedURL         string
+	flagFeedSince       string
+	flagFeedStateFile   string
+	flagFeedLimit       int
+	flagFeedRead        bool
+	flagFeedFormat      string
+	flagFeedTimeout     int
+	flagFeedOutputFile  string
+	flagFeedMaxRetries  int
+	flagFeedRetryDelay  int
+	flagFeedHeaders     []string
+	flagFeedConcurrency int
+	flagFeedReadOptions readOptions
+)
+
+func init() {
+	FeedCmd.Flags().StringVarP(&flagFeedURL, "url", "u", "", "Feed URL (required)")
+	FeedCmd.Flags().StringVar(&flagFeedSince, "since", "", "Only entries published at or after this time (2024-05-01, RFC 3339, or a duration like 72h or 7d)")
+	FeedCmd.Flags().StringVar(&flagFeedStateFile, "state", "", "State file (JSONL) of seen entries; entries recorded there are skipped on later runs")
+	FeedCmd.Flags().IntVarP(&flagFeedLimit, "limit", "l", 0, "Max number of entries to return (default: all)")
+	FeedCmd.Flags().BoolVar(&flagFeedRead, "read", false, "Read each entry's link like 'jina read' and return the full content")
+	FeedCmd.Flags().StringVarP(&flagFeedFormat, "format", "F", "", "Response format for --read: markdown, html, text (default: markdown)")
+	FeedCmd.Flags().IntVarP(&flagFeedTimeout, "timeout", "t", 0, "Request timeout in seconds")
+	FeedCmd.Flags().StringVarP(&flagFeedOutputFile, "output-file", "O", "", "Write output to file instead of stdout")
+	FeedCmd.Flags().IntVar(&flagFeedMaxRetries, "max-retries", 0, "Max retries for transient failures (default: config max_retries)")
+	FeedCmd.Flags().IntVar(&flagFeedRetryDelay, "retry-delay", 0, "Initial retry delay in milliseconds (default: config retry_delay)")
+	FeedCmd.Flags().StringArrayVarP(&flagFeedHeaders, "header", "H", nil, "Extra request header 'Name: value' (repeatable, added to config [headers])")
+	FeedCmd.Flags().IntVarP(&flagFeedConcurrency, "concurrency", "c", 0, "Number of entries to read in parallel with --read (default: config concurrency)")
+	addReadFlags(FeedCmd, &flagFeedReadOptions)
+}
+
+func validateFeedFlags() error {
+	if flagFeedURL == "" {
+		return fmt.Errorf("必须提供 --url 参数")
+	}
+	if flagFeedSince != "" {
+		if _, err := parseSince(flagFeedSince); err != nil {
+			return fmt.Errorf("无效的 --since: %w", err)
+		}
+	}
+	if flagFeedLimit < 0 || flagFeedConcurrency < 0 {
+		return fmt.Errorf("--limit 和 --concurrency 不能为负数")
+	}
+	if flagFeedMaxRetries < 0 || flagFeedRetryDelay < 0 {
+		return fmt.Errorf("--max-retries 和 --retry-delay 不能为负数")
+	}
+	if err := flagFeedReadOptions.validate(); err != nil {
+		return err
+	}
+	if flagFeedFormat != "" && !flagFeedRead {
+		return fmt.Errorf("--format 需要同时指定 --read")
+	}
+	for _, h := range flagFeedHeaders {
+		if _, _, err := httpheader.Parse(h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runFeed(cmd *cobra.Command, args []string) {
+	// 获取输出格式
+	outputFormat := getReadOutputFormat(cmd)
+
+	// 创建 API 客户端
+	client := newReadClient(cmd, flagFeedTimeout, flagFeedMaxRetries, flagFeedRetryDelay, flagFeedHeaders, &flagFeedReadOptions)
+
+	// 获取输出处理器
+	out, err := output.GetOutput(output.OutputFormat(outputFormat), flagFeedOutputFile)
+	if err != nil {
+		output.Error(api.NewError(api.CodeInternal, err))
+	}
+	defer closeOutput(out)
+
+	processFeed(cmd.Context(), client, responseFormatOf(flagFeedFormat), outputFormat == "markdown", out)
+}
+
+// processFeed 获取订阅源，过滤出新条目后列出或读取
+//
+// 条目按订阅源中的顺序输出。--since 跳过发布时间更早的条目（没有发布时间的条目保留）；
+// --state 跳过状态文件中已记录的条目，本次输出的条目随即写入状态文件，
+// --read 读取失败的条目不视为已见，下次运行时重试。
+func processFeed(ctx context.Context, client *api.Client, responseFormat string, showProgress bool, out output.Output) {
+	data, err := client.DownloadContext(ctx, flagFeedURL)
+	if err != nil {
+		_ = out.Error(err)
+		return
+	}
+	f, err := feed.Parse(data, flagFeedURL)
+	if err != nil {
+		_ = out.Error(api.NewError(api.CodeInvalidInput, err))
+		return
+	}
+
+	var since time.Time
+	if flagFeedSince != "" {
+		since, _ = parseSince(flagFeedSince)
+	}
+
+	var store *state.Store
+	if flagFeedStateFile != "" {
+		store, err = state.Open(flagFeedStateFile)
+		if err != nil {
+			_ = out.Error(api.NewError(api.CodeInternal, err))
+			return
+		}
+		defer store.Close()
+	}
+
+	var entries []feed.Entry
+	for _, entry := range f.Entries {
+		if flagFeedLimit > 0 && len(entries) >= flagFeedLimit {
+			break
+		}
+		if !since.IsZero() && !entry.Published.IsZero() && entry.Published.Before(since) {
+			continue
+		}
+		if store != nil {
+			if rec, ok := store.Lookup(entry.Key()); ok && rec.OK {
+				continue
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	if showProgress {
+		fmt.Fprintf(os.Stderr, "%s: %d 个条目，%d 个新条目\n", feedName(f), len(f.Entries), len(entries))
+	}
+
+	collector := newBatchCollector(out, len(entries), showProgress && flagFeedRead)
+	collector.summary = map[string]interface{}{"feed": feedName(f)}
+
+	concurrency := 1
+	if flagFeedRead {
+		concurrency = batchConcurrency(flagFeedConcurrency)
+	}
+	forEachConcurrent(ctx, len(entries), concurrency, func(i int) {
+		entry := entries[i]
+		result := feedEntryToMap(entry)
+		if flagFeedRead && entry.Link != "" {
+			read := readBatchURL(ctx, client, buildReadRequest(entry.Link, responseFormat, &flagFeedReadOptions))
+			if read == nil {
+				return
+			}
+			if _, ok := read["title"]; !ok && entry.Title != "" {
+				read["title"] = entry.Title
+			}
+			if published, ok := result["published"]; ok {
+				read["published"] = published
+			}
+			result = read
+		}
+
+		if store != nil {
+			_, failed := result["error"]
+			if err := store.Append(state.Record{URL: entry.Key(), OK: !failed, Result: feedEntryToMap(entry)}); err != nil {
+				fmt.Fprintf(os.Stderr, "警告: %v\n", err)
+			}
+		}
+		collector.add(i, entry.Link, result)
+	})
+
+	interrupted := ctx.Err() != nil
+	if interrupted && store != nil {
+		store.Close()
+	}
+	collector.finish(interrupted)
+}
+
+// feedName 返回订阅源标题，没有时为 --url
+func feedName(f *feed.Feed) string {
+	if f.Title != "" {
+		return f.Title
+	}
+	return flagFeedURL
+}
+
+// feedEntryToMap 将条目转换为输出数据，空字段不输出
+func feedEntryToMap(entry feed.Entry) map[string]interface{} {
+	result := map[string]interface{}{}
+	if entry.Title != "" {
+		result["title"] = entry.Title
+	}
+	if entry.Link != "" {
+		result["url"] = entry.Link
+	}
+	if entry.ID != "" && entry.ID != entry.Link {
+		result["id"] = entry.ID
+	}
+	if !entry.Published.IsZero() {
+		result["published"] = entry.Published.Format(time.RFC3339)
+	}
+	return result
+}
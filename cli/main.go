@@ -1,11 +1,20 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/geekjourneyx/jina-cli/cli/pkg/api"
 	"github.com/geekjourneyx/jina-cli/cli/pkg/config"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/httpheader"
 	"github.com/geekjourneyx/jina-cli/cli/pkg/output"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/sitemap"
 	"github.com/spf13/cobra"
 )
 
@@ -17,10 +26,14 @@ var (
 )
 
 func main() {
+	// Ctrl-C / SIGTERM 取消根 context，正在进行的请求随之停止
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// 执行根命令
-	if err := rootCmd.Execute(); err != nil {
-		output.Error(err)
-		os.Exit(1)
+	// 未携带错误码的错误来自参数解析和校验
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		output.Error(api.NewError(api.CodeInvalidInput, err))
 	}
 }
 
@@ -49,11 +62,133 @@ func initConfig(cmd *cobra.Command, args []string) error {
 	var err error
 	cfg, err = config.Load()
 	if err != nil {
-		return fmt.Errorf("加载配置失败: %w", err)
+		return api.NewError(api.CodeInternal, fmt.Errorf("加载配置失败: %w", err))
 	}
 	return nil
 }
 
+// configureClient 根据配置和命令行参数设置客户端的重试策略、本地缓存和详细日志
+func configureClient(cmd *cobra.Command, client *api.Client, maxRetries, retryDelay int) {
+	policy := api.RetryPolicy{
+		MaxRetries: cfg.MaxRetries,
+		BaseDelay:  time.Duration(cfg.RetryDelay) * time.Millisecond,
+		MaxDelay:   api.DefaultRetryMaxDelay,
+	}
+	if cmd.Flags().Changed("max-retries") {
+		policy.MaxRetries = maxRetries
+	}
+	if cmd.Flags().Changed("retry-delay") {
+		policy.BaseDelay = time.Duration(retryDelay) * time.Millisecond
+	}
+	client.SetRetryPolicy(policy)
+
+	// cache_tolerance 设置时启用本地缓存
+	if cacheTTL() > 0 {
+		client.SetCache(openCache())
+	}
+
+	if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
+		client.SetLogger(os.Stderr)
+	}
+}
+
+// serverTimeoutMargin HTTP 客户端超时比服务端超时多留的秒数
+const serverTimeoutMargin = 10
+
+// resolveTimeouts 返回 HTTP 客户端超时和服务端超时（秒）
+//
+// 设置了服务端超时（X-Timeout）时，客户端超时至少比它长 serverTimeoutMargin 秒，
+// 使慢页面由服务端返回明确的超时错误，而不是在客户端因截止时间到达而失败。
+func resolveTimeouts(timeout, serverTimeout int) (int, int) {
+	if serverTimeout <= 0 {
+		serverTimeout = cfg.ServerTimeout
+	}
+	if timeout > 0 && serverTimeout > 0 && timeout < serverTimeout+serverTimeoutMargin {
+		timeout = serverTimeout + serverTimeoutMargin
+	}
+	return timeout, serverTimeout
+}
+
+// applyCacheTolerance 命令行指定 --cache-tolerance 时覆盖配置，同时作为本地缓存有效期
+func applyCacheTolerance(cmd *cobra.Command, seconds int) {
+	if cmd.Flags().Changed("cache-tolerance") {
+		cfg.CacheTolerance = strconv.Itoa(seconds)
+	}
+}
+
+// tokenBudget 返回 Token 预算，命令行参数优先
+func tokenBudget(flagValue int) int {
+	if flagValue > 0 {
+		return flagValue
+	}
+	return cfg.TokenBudget
+}
+
+// batchConcurrency 返回并发数，命令行参数优先
+func batchConcurrency(flagValue int) int {
+	if flagValue > 0 {
+		return flagValue
+	}
+	return cfg.Concurrency
+}
+
+// parseSince 解析 --since：W3C/RFC 3339 时间（如 2024-05-01），或表示“多久以前”的时长（如 72h、7d）
+func parseSince(s string) (time.Time, error) {
+	if t, err := sitemap.ParseTime(s); err == nil {
+		return t, nil
+	}
+	if d, err := parseAge(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("%q 不是日期、RFC 3339 时间或时长", s)
+}
+
+// parseAge 解析时长：天数（如 7d）或 Go 时长（如 72h、90m），不能为负数
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("%q 不是有效的时长", s)
+}
+
+// requestHeaders 合并配置文件中的默认请求头和 --header 参数（后者优先）
+func requestHeaders(flagHeaders []string) (map[string]string, error) {
+	headers := make(map[string]string, len(cfg.Headers)+len(flagHeaders))
+	for name, value := range cfg.Headers {
+		headers[name] = value
+	}
+	for _, h := range flagHeaders {
+		name, value, err := httpheader.Parse(h)
+		if err != nil {
+			return nil, err
+		}
+		headers[name] = value
+	}
+	if len(headers) == 0 {
+		return nil, nil
+	}
+	return headers, nil
+}
+
+// closeOutput 关闭输出处理器（如果支持）
+func closeOutput(out output.Output) {
+	if closer, ok := out.(interface{ Close() error }); ok {
+		closer.Close()
+	}
+}
+
+// exitInterrupted 在批量处理被中断时提示并以中断退出码退出
+func exitInterrupted(out output.Output, done, total int) {
+	closeOutput(out)
+	fmt.Fprintf(os.Stderr, "已中断：已输出 %d/%d 个结果\n", done, total)
+	os.Exit(api.ExitInterrupted)
+}
+
 func init() {
 	// 添加版本标志
 	rootCmd.Version = fmt.Sprintf("%s (构建时间: %s, 提交: %s)", version, buildDate, gitCommit)
@@ -61,12 +196,15 @@ func init() {
 	// 添加子命令
 	rootCmd.AddCommand(ReadCmd)
 	rootCmd.AddCommand(SearchCmd)
+	rootCmd.AddCommand(CrawlCmd)
+	rootCmd.AddCommand(FeedCmd)
 	rootCmd.AddCommand(ConfigCmd)
+	rootCmd.AddCommand(CacheCmd)
 
 	// 持久化标志
 	rootCmd.PersistentFlags().StringP("api-base", "a", "", "API base URL (overrides config)")
 	rootCmd.PersistentFlags().StringP("api-key", "k", "", "API key (overrides config)")
-	rootCmd.PersistentFlags().StringP("output", "o", "", "Output format: json, markdown (default: json)")
+	rootCmd.PersistentFlags().StringP("output", "o", "", "Output format: json, markdown, ndjson (default: json)")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Verbose output")
 
 	// 绑定持久化标志到配置
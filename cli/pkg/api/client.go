@@ -1,10 +1,19 @@
 package api
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -15,9 +24,22 @@ type Client struct {
 	searchAPIURL string
 	apiKey       string
 	httpClient   *http.Client
+	retry        RetryPolicy
+	logger       io.Writer
+	cache        Cache
+}
+
+// Cache 响应缓存，由 pkg/cache 实现
+type Cache interface {
+	// Get 返回未过期的缓存内容
+	Get(key string) ([]byte, bool)
+	// Put 写入缓存，url 仅用于展示
+	Put(key, url string, body []byte) error
 }
 
 // NewClient 创建 API 客户端
+//
+// 默认不重试，需要时通过 SetRetryPolicy 设置重试策略。
 func NewClient(readURL, searchURL, apiKey string, timeout int) *Client {
 	return &Client{
 		readAPIURL:   readURL,
@@ -34,127 +56,453 @@ func (c *Client) SetTimeout(timeout int) {
 	c.httpClient.Timeout = time.Duration(timeout) * time.Second
 }
 
+// SetRetryPolicy 设置重试策略
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retry = policy
+}
+
+// SetLogger 设置详细日志输出（如 os.Stderr），nil 表示不输出
+func (c *Client) SetLogger(w io.Writer) {
+	c.logger = w
+}
+
+// SetCache 设置响应缓存，nil 表示不使用缓存
+func (c *Client) SetCache(cache Cache) {
+	c.cache = cache
+}
+
+// logf 输出详细日志
+func (c *Client) logf(format string, args ...interface{}) {
+	if c.logger != nil {
+		fmt.Fprintf(c.logger, "[jina] "+format+"\n", args...)
+	}
+}
+
 // Read 执行 Read API 请求
 func (c *Client) Read(req *ReadRequest) (*ReadResponse, error) {
-	// 构建请求 URL
-	var fullURL string
-	var body io.Reader
+	return c.ReadContext(context.Background(), req)
+}
 
-	if req.PostMethod {
-		// POST 方法用于 SPA 带 hash 路由的情况
-		fullURL = c.readAPIURL
-		formData := url.Values{}
-		formData.Set("url", req.URL)
-		body = strings.NewReader(formData.Encode())
-	} else {
-		// GET 方法
-		fullURL = c.readAPIURL + "/" + url.PathEscape(req.URL)
+// ReadContext 执行 Read API 请求，ctx 取消或超时后立即返回
+func (c *Client) ReadContext(ctx context.Context, req *ReadRequest) (*ReadResponse, error) {
+	if options := describeFetchOptions(req); options != "" {
+		c.logf("读取 %s（%s）", req.URL, options)
+	}
+	content, err := c.doCached(ctx, req.NoCache, func() (*http.Request, error) {
+		return c.newReadRequest(ctx, req)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// 创建 HTTP 请求
-	var err error
+	// 构建响应
+	var resp *ReadResponse
+	if req.JSONResponse {
+		resp = parseReadResponse(content, req.URL)
+	}
+	if resp == nil {
+		resp = &ReadResponse{
+			Content: string(content),
+			URL:     req.URL,
+		}
+	}
+
+	// 摘要未以结构化字段返回时，从正文末尾拆出
+	if (req.WithLinksSummary && len(resp.Links) == 0) || (req.WithImagesSummary && len(resp.Images) == 0) {
+		body, links, images := extractSummaries(resp.Content)
+		resp.Content = body
+		if len(resp.Links) == 0 {
+			resp.Links = links
+		}
+		if len(resp.Images) == 0 {
+			resp.Images = images
+		}
+	}
+	return resp, nil
+}
+
+// describeFetchOptions 描述影响目标页面抓取方式的选项，用于详细日志
+func describeFetchOptions(req *ReadRequest) string {
+	var parts []string
+	for _, option := range []struct{ name, value string }{
+		{"engine", req.Engine},
+		{"locale", req.Locale},
+		{"user-agent", req.UserAgent},
+		{"referer", req.Referer},
+	} {
+		if option.value != "" {
+			parts = append(parts, option.name+"="+option.value)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// newReadRequest 构建 Read API 的 HTTP 请求
+func (c *Client) newReadRequest(ctx context.Context, req *ReadRequest) (*http.Request, error) {
 	var httpReq *http.Request
+	var err error
 
-	if req.PostMethod {
-		httpReq, err = http.NewRequest("POST", fullURL, body)
+	if req.HTML != "" || len(req.PDF) > 0 {
+		// 直接提交本地文档，url 作为解析相对链接的基础 URL
+		payload := map[string]string{}
+		if req.URL != "" {
+			payload["url"] = req.URL
+		}
+		if len(req.PDF) > 0 {
+			payload["pdf"] = base64.StdEncoding.EncodeToString(req.PDF)
+		} else {
+			payload["html"] = req.HTML
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		httpReq, err = http.NewRequestWithContext(ctx, "POST", c.readAPIURL, bytes.NewReader(body))
 		if err != nil {
-			return nil, fmt.Errorf("创建请求失败: %w", err)
+			return nil, err
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+	} else if req.PostMethod {
+		// POST 方法用于 SPA 带 hash 路由的情况
+		formData := url.Values{}
+		formData.Set("url", req.URL)
+		httpReq, err = http.NewRequestWithContext(ctx, "POST", c.readAPIURL, strings.NewReader(formData.Encode()))
+		if err != nil {
+			return nil, err
 		}
 		httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	} else {
-		httpReq, err = http.NewRequest("GET", fullURL, nil)
+		// GET 方法
+		httpReq, err = http.NewRequestWithContext(ctx, "GET", c.readAPIURL+"/"+url.PathEscape(req.URL), nil)
 		if err != nil {
-			return nil, fmt.Errorf("创建请求失败: %w", err)
+			return nil, err
 		}
 	}
 
 	// 设置请求头
 	c.setCommonHeaders(httpReq)
+	if req.JSONResponse {
+		httpReq.Header.Set("Accept", "application/json")
+	}
 	c.setRequestHeaders(httpReq, req)
+	return httpReq, nil
+}
 
-	// 发送请求
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("请求失败: %w", err)
+// parseReadResponse 解析 JSON 模式的 Read 响应，无法解析时返回 nil
+func parseReadResponse(body []byte, requestURL string) *ReadResponse {
+	var env readEnvelope
+	if err := json.Unmarshal(body, &env); err != nil || env.Data == nil {
+		return nil
 	}
-	defer resp.Body.Close()
 
-	// 检查 HTTP 状态码
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("HTTP 错误: %d, 响应: %s", resp.StatusCode, string(bodyBytes))
+	d := env.Data
+	// JSON 模式下 html/text 格式的正文分别在 html/text 字段中，
+	// screenshot/pageshot 格式返回图片 URL 而非正文
+	content := d.Content
+	for _, alt := range []string{d.HTML, d.Text, d.ScreenshotURL, d.PageshotURL} {
+		if content != "" {
+			break
+		}
+		content = alt
 	}
+	usage := d.Usage
+	if usage.Tokens == 0 {
+		usage = env.Meta.Usage
+	}
+	return &ReadResponse{
+		Content:       content,
+		URL:           requestURL,
+		FinalURL:      d.URL,
+		Title:         d.Title,
+		Description:   d.Description,
+		PublishedTime: d.PublishedTime,
+		Images:        d.Images.images(),
+		Links:         d.Links.links(),
+		Warning:       d.Warning,
+		Usage:         usage,
+	}
+}
 
-	// 读取响应
-	content, err := io.ReadAll(resp.Body)
+// Search 执行 Search API 请求
+func (c *Client) Search(req *SearchRequest) (*SearchResponse, error) {
+	return c.SearchContext(context.Background(), req)
+}
+
+// SearchContext 执行 Search API 请求，ctx 取消或超时后立即返回
+func (c *Client) SearchContext(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	content, err := c.doCached(ctx, req.NoCache, func() (*http.Request, error) {
+		return c.newSearchRequest(ctx, req)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("读取响应失败: %w", err)
+		return nil, err
 	}
 
-	// 构建响应
-	return &ReadResponse{
-		Content: string(content),
-		URL:     req.URL,
+	// 解析搜索结果（优先 JSON 格式，兼容纯文本格式）
+	results, usage := parseSearchResults(string(content), req.ResponseFormat)
+	if req.NoContent {
+		for i := range results {
+			results[i].Content = ""
+		}
+	}
+
+	return &SearchResponse{
+		Query:   req.Query,
+		Results: results,
+		Usage:   usage,
 	}, nil
 }
 
-// Search 执行 Search API 请求
-func (c *Client) Search(req *SearchRequest) (*SearchResponse, error) {
+// SearchPagesContext 从 req.Page（默认第 1 页）开始连续获取 pages 页搜索结果并合并
+//
+// 结果按 NormalizeURL 去重，保留首次出现的位置；某一页没有结果或结果数少于 req.Limit 时提前结束。
+func (c *Client) SearchPagesContext(ctx context.Context, req *SearchRequest, pages int) (*SearchResponse, error) {
+	merged := &SearchResponse{Query: req.Query}
+	seen := make(map[string]bool)
+
+	pageReq := *req
+	if pageReq.Page < 1 {
+		pageReq.Page = 1
+	}
+	for i := 0; i < pages; i++ {
+		resp, err := c.SearchContext(ctx, &pageReq)
+		if err != nil {
+			return nil, err
+		}
+		merged.Usage.Tokens += resp.Usage.Tokens
+		for _, result := range resp.Results {
+			if key := NormalizeURL(result.URL); key != "" {
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+			}
+			merged.Results = append(merged.Results, result)
+		}
+		if len(resp.Results) == 0 || (req.Limit > 0 && len(resp.Results) < req.Limit) {
+			break
+		}
+		pageReq.Page++
+	}
+	return merged, nil
+}
+
+// DownloadContext 下载 API 返回的资源（如截图），按 RetryPolicy 重试
+//
+// 资源通常位于第三方存储，因此不发送 Authorization 头。
+func (c *Client) DownloadContext(ctx context.Context, resourceURL string) ([]byte, error) {
+	return c.do(ctx, func() (*http.Request, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, "GET", resourceURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("User-Agent", "jina-cli/1.0.0")
+		return httpReq, nil
+	})
+}
+
+// newSearchRequest 构建 Search API 的 HTTP 请求
+func (c *Client) newSearchRequest(ctx context.Context, req *SearchRequest) (*http.Request, error) {
 	// 构建查询 URL
 	queryParams := url.Values{}
-	if len(req.Sites) > 0 {
-		for _, site := range req.Sites {
-			queryParams.Add("site", site)
-		}
+	for _, site := range req.Sites {
+		queryParams.Add("site", site)
+	}
+	if req.Limit > 0 {
+		queryParams.Set("num", strconv.Itoa(req.Limit))
+	}
+	if req.Page > 0 {
+		queryParams.Set("page", strconv.Itoa(req.Page))
+	}
+	if req.Country != "" {
+		queryParams.Set("gl", req.Country)
+	}
+	if req.Language != "" {
+		queryParams.Set("hl", req.Language)
 	}
 
 	// 编码查询字符串
-	queryEncoded := url.QueryEscape(req.Query)
-	fullURL := c.searchAPIURL + "/" + queryEncoded
-	if queryParams.Encode() != "" {
-		fullURL += "?" + queryParams.Encode()
+	fullURL := c.searchAPIURL + "/" + url.QueryEscape(req.Query)
+	if encoded := queryParams.Encode(); encoded != "" {
+		fullURL += "?" + encoded
 	}
 
-	// 创建 HTTP 请求
-	httpReq, err := http.NewRequest("GET", fullURL, nil)
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %w", err)
+		return nil, err
 	}
 
 	// 设置请求头
 	c.setCommonHeaders(httpReq)
+	httpReq.Header.Set("Accept", "application/json")
+	switch {
+	case req.NoContent:
+		// 不抓取结果页面，只返回搜索引擎的标题、URL 和描述
+		httpReq.Header.Set("X-Respond-With", "no-content")
+	case req.ResponseFormat != "":
+		httpReq.Header.Set("X-Respond-With", req.ResponseFormat)
+	}
+	if req.NoCache {
+		httpReq.Header.Set("X-No-Cache", "true")
+	}
+	setLimitHeaders(httpReq, req.Timeout, req.CacheTolerance, req.TokenBudget)
 	for k, v := range req.Headers {
 		httpReq.Header.Set(k, v)
 	}
+	return httpReq, nil
+}
+
+// doCached 在设置了缓存时先查找缓存，未命中时发送请求并写入缓存
+//
+// noCache 为 true 时跳过缓存查找，但仍用新的响应刷新缓存。
+func (c *Client) doCached(ctx context.Context, noCache bool, newReq func() (*http.Request, error)) ([]byte, error) {
+	if c.cache == nil {
+		return c.do(ctx, newReq)
+	}
 
-	// 发送请求
-	resp, err := c.httpClient.Do(httpReq)
+	httpReq, err := newReq()
 	if err != nil {
-		return nil, fmt.Errorf("请求失败: %w", err)
+		return nil, &Error{Code: CodeInvalidInput, Message: "创建请求失败", Err: err}
 	}
-	defer resp.Body.Close()
+	key, err := cacheKey(httpReq)
+	if err != nil {
+		return c.do(ctx, newReq)
+	}
+	requestURL := httpReq.URL.Redacted()
 
-	// 检查 HTTP 状态码
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("HTTP 错误: %d, 响应: %s", resp.StatusCode, string(bodyBytes))
+	if !noCache {
+		if content, ok := c.cache.Get(key); ok {
+			c.logf("缓存命中: %s %s", httpReq.Method, requestURL)
+			return content, nil
+		}
 	}
 
-	// 读取响应
-	content, err := io.ReadAll(resp.Body)
+	content, err := c.do(ctx, newReq)
 	if err != nil {
-		return nil, fmt.Errorf("读取响应失败: %w", err)
+		return nil, err
+	}
+	if err := c.cache.Put(key, requestURL, content); err != nil {
+		c.logf("写入缓存失败: %v", err)
 	}
+	return content, nil
+}
+
+// cacheKeyIgnoredHeaders 不影响响应内容、不参与缓存键计算的请求头
+var cacheKeyIgnoredHeaders = map[string]bool{
+	"Authorization":     true,
+	"User-Agent":        true,
+	"X-No-Cache":        true,
+	"X-Cache-Tolerance": true,
+}
 
-	// 解析搜索结果（从 JSON 格式或纯文本格式）
-	// Search API 返回的是 JSON 格式的结果数组
-	// 如果没有指定 Accept: application/json，返回纯文本
-	results := parseSearchResults(string(content), req.ResponseFormat)
+// cacheKey 根据请求方法、URL、影响结果的请求头和请求体计算缓存键
+func cacheKey(req *http.Request) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", req.Method, req.URL.String())
 
-	return &SearchResponse{
-		Query:   req.Query,
-		Results: results,
-	}, nil
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		if !cacheKeyIgnoredHeaders[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(h, "%s: %s\n", name, strings.Join(req.Header[name], ", "))
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return "", err
+		}
+		defer body.Close()
+		if _, err := io.Copy(h, body); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// do 发送请求并返回 200 响应的内容
+//
+// newReq 每次尝试都会被调用，以便重新构建请求体。
+// 传输错误和可重试的状态码按 RetryPolicy 重试，失败时返回 *Error。
+func (c *Client) do(ctx context.Context, newReq func() (*http.Request, error)) ([]byte, error) {
+	maxAttempts := c.retry.MaxRetries + 1
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		httpReq, err := newReq()
+		if err != nil {
+			return nil, &Error{Code: CodeInvalidInput, Message: "创建请求失败", Err: err}
+		}
+		requestURL := httpReq.URL.Redacted()
+		c.logf("%s %s（第 %d/%d 次尝试）", httpReq.Method, requestURL, attempt, maxAttempts)
+		if attempt == 1 && c.logger != nil {
+			if headers := formatHeaders(httpReq.Header); headers != "" {
+				c.logf("请求头: %s", headers)
+			}
+		}
+
+		// 发送请求
+		resp, err := c.httpClient.Do(httpReq)
+		if err != nil {
+			apiErr := newTransportError(httpReq.Method, requestURL, err)
+			if attempt < maxAttempts && apiErr.Retryable {
+				wait := c.retry.wait(attempt, 0)
+				c.logf("请求失败: %v，%s 后重试", err, wait.Round(time.Millisecond))
+				if err := sleepContext(ctx, wait); err != nil {
+					return nil, newContextError(requestURL, err)
+				}
+				continue
+			}
+			return nil, apiErr
+		}
+
+		// 读取响应
+		content, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		// 检查 HTTP 状态码
+		if resp.StatusCode != http.StatusOK {
+			apiErr := newHTTPError(httpReq.Method, requestURL, resp.StatusCode, content)
+			if attempt < maxAttempts && apiErr.Retryable {
+				retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+				wait := c.retry.wait(attempt, retryAfter)
+				c.logf("HTTP %d，%s 后重试", resp.StatusCode, wait.Round(time.Millisecond))
+				if err := sleepContext(ctx, wait); err != nil {
+					return nil, newContextError(requestURL, err)
+				}
+				continue
+			}
+			return nil, apiErr
+		}
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, newContextError(requestURL, ctxErr)
+			}
+			return nil, &Error{Code: CodeNetworkError, Message: "读取响应失败", URL: requestURL, Err: err}
+		}
+
+		if attempt > 1 {
+			c.logf("第 %d 次尝试成功", attempt)
+		}
+		return content, nil
+	}
+}
+
+// sleepContext 等待指定时间，ctx 结束时提前返回其错误
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 // setCommonHeaders 设置通用请求头
@@ -180,6 +528,14 @@ func (c *Client) setRequestHeaders(req *http.Request, readReq *ReadRequest) {
 		req.Header.Set("X-With-Generated-Alt", "true")
 	}
 
+	// 链接和图片摘要；链接使用 all，说明文字相同的链接不会被合并（响应为数组形式）
+	if readReq.WithLinksSummary {
+		req.Header.Set("X-With-Links-Summary", "all")
+	}
+	if readReq.WithImagesSummary {
+		req.Header.Set("X-With-Images-Summary", "true")
+	}
+
 	// 禁用缓存
 	if readReq.NoCache {
 		req.Header.Set("X-No-Cache", "true")
@@ -200,36 +556,179 @@ func (c *Client) setRequestHeaders(req *http.Request, readReq *ReadRequest) {
 		req.Header.Set("X-Wait-For-Selector", readReq.WaitForSelector)
 	}
 
+	// 移除指定元素（多个选择器合并为一个选择器列表）
+	if len(readReq.RemoveSelectors) > 0 {
+		req.Header.Set("X-Remove-Selector", strings.Join(readReq.RemoveSelectors, ", "))
+	}
+
+	// 图片保留方式
+	if readReq.RetainImages != "" {
+		req.Header.Set("X-Retain-Images", readReq.RetainImages)
+	}
+
+	// iframe 和 Shadow DOM 内容
+	if readReq.WithIframe {
+		req.Header.Set("X-With-Iframe", "true")
+	}
+	if readReq.WithShadowDOM {
+		req.Header.Set("X-With-Shadow-Dom", "true")
+	}
+
 	// 设置 Cookie
 	if readReq.Cookie != "" {
 		req.Header.Set("X-Set-Cookie", readReq.Cookie)
 	}
 
+	// 抓取引擎、语言区域、User-Agent 和 Referer
+	if readReq.Engine != "" {
+		req.Header.Set("X-Engine", readReq.Engine)
+	}
+	if readReq.Locale != "" {
+		req.Header.Set("X-Locale", readReq.Locale)
+	}
+	if readReq.UserAgent != "" {
+		req.Header.Set("X-User-Agent", readReq.UserAgent)
+	}
+	if readReq.Referer != "" {
+		req.Header.Set("X-Referer", readReq.Referer)
+	}
+
+	// 服务端超时、缓存容忍度和 Token 预算
+	setLimitHeaders(req, readReq.Timeout, readReq.CacheTolerance, readReq.TokenBudget)
+
 	// 自定义请求头
 	for k, v := range readReq.Headers {
 		req.Header.Set(k, v)
 	}
 }
 
+// setLimitHeaders 设置服务端超时、缓存容忍度和 Token 预算请求头，零值不发送
+func setLimitHeaders(req *http.Request, timeout int, cacheTolerance string, tokenBudget int) {
+	if timeout > 0 {
+		req.Header.Set("X-Timeout", strconv.Itoa(timeout))
+	}
+	if cacheTolerance != "" {
+		req.Header.Set("X-Cache-Tolerance", cacheTolerance)
+	}
+	if tokenBudget > 0 {
+		req.Header.Set("X-Token-Budget", strconv.Itoa(tokenBudget))
+	}
+}
+
 // parseSearchResults 解析搜索结果
-func parseSearchResults(content string, format string) []SearchResult {
-	// 如果是 JSON 格式，需要解析 JSON
-	// 如果是纯文本格式，按行分割
+//
+// 请求时设置了 Accept: application/json，Search API 返回 JSON 信封：
+//
+//	{"code":200,"status":20000,"data":[{"title":...,"url":...}],"meta":{"usage":{"tokens":N}}}
+//
+// 如果服务端返回的不是 JSON（例如自建代理），回退到纯文本解析。
+func parseSearchResults(content string, format string) ([]SearchResult, Usage) {
+	trimmed := strings.TrimSpace(content)
+
+	switch {
+	case strings.HasPrefix(trimmed, "{"):
+		var env searchEnvelope
+		if err := json.Unmarshal([]byte(trimmed), &env); err == nil {
+			return convertSearchResults(env.Data, env.Meta.Usage)
+		}
+	case strings.HasPrefix(trimmed, "["):
+		// 部分代理直接返回结果数组
+		var data []searchEnvResult
+		if err := json.Unmarshal([]byte(trimmed), &data); err == nil {
+			return convertSearchResults(data, Usage{})
+		}
+	}
+
+	return parseTextSearchResults(content), Usage{}
+}
+
+// convertSearchResults 将 JSON 结果转换为 SearchResult，缺少总用量时按结果累加
+func convertSearchResults(data []searchEnvResult, usage Usage) ([]SearchResult, Usage) {
+	results := make([]SearchResult, 0, len(data))
+	sum := 0
+	for _, d := range data {
+		results = append(results, SearchResult{
+			Title:       d.Title,
+			URL:         d.URL,
+			Description: d.Description,
+			Content:     d.Content,
+			Date:        d.Date,
+			Usage:       d.Usage,
+		})
+		sum += d.Usage.Tokens
+	}
+	if usage.Tokens == 0 {
+		usage.Tokens = sum
+	}
+	return results, usage
+}
+
+// textResultLine 匹配纯文本响应中的 "[1] Title: xxx" 行
+var textResultLine = regexp.MustCompile(`^\[(\d+)\] ([A-Za-z ]+):(?: (.*))?$`)
+
+// parseTextSearchResults 解析纯文本格式的搜索结果
+//
+// Search API 的纯文本格式为：
+//
+//	[1] Title: ...
+//	[1] URL Source: ...
+//	[1] Description: ...
+//	[1] Markdown Content:
+//	...
+//
+// 无法识别时，每个非空行作为一个结果。
+func parseTextSearchResults(content string) []SearchResult {
 	results := []SearchResult{}
+	lines := strings.Split(content, "\n")
 
-	// 简单实现：按行分割，每行是一个结果
-	// 实际上 Search API 返回的是 JSON 格式
-	// 这里先做一个简单的实现，后续可以根据需要改进
+	var current *SearchResult
+	var index string
+	var body []string
+	inContent := false
+	flush := func() {
+		if current != nil {
+			current.Content = strings.TrimSpace(strings.Join(body, "\n"))
+			results = append(results, *current)
+		}
+		current, body, inContent = nil, nil, false
+	}
+
+	for _, line := range lines {
+		m := textResultLine.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if m == nil {
+			if inContent {
+				body = append(body, strings.TrimRight(line, "\r"))
+			}
+			continue
+		}
+		if current == nil || m[1] != index {
+			flush()
+			current = &SearchResult{}
+			index = m[1]
+		}
+		switch m[2] {
+		case "Title":
+			current.Title = m[3]
+		case "URL Source":
+			current.URL = m[3]
+		case "Description":
+			current.Description = m[3]
+		case "Published Time", "Date":
+			current.Date = m[3]
+		case "Markdown Content", "Content", "HTML Content", "Text Content":
+			inContent = true
+			if m[3] != "" {
+				body = append(body, m[3])
+			}
+		}
+	}
+	flush()
 
-	// 尝试检测是否是 JSON 格式
-	if strings.HasPrefix(strings.TrimSpace(content), "[") {
-		// TODO: 实现 JSON 解析
-		// 目前先返回空结果
+	if len(results) > 0 {
 		return results
 	}
 
-	// 纯文本格式，每行是一个结果
-	lines := strings.Split(content, "\n")
+	// 无法识别格式，每行是一个结果
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
 		if line != "" {
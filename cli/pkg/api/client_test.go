@@ -1,8 +1,11 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -111,6 +114,40 @@ func TestClient_Read_WithHeaders(t *testing.T) {
 	}
 }
 
+func TestClient_Read_ContentShapingHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := map[string]string{
+			"X-Remove-Selector": "nav, .cookie-banner",
+			"X-Retain-Images":   "none",
+			"X-With-Iframe":     "true",
+			"X-With-Shadow-Dom": "true",
+		}
+		for header, value := range want {
+			if got := r.Header.Get(header); got != value {
+				t.Errorf("Expected %s %q, got %q", header, value, got)
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("content"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", server.URL+"/", "", 30)
+
+	req := &ReadRequest{
+		URL:             "https://example.com",
+		RemoveSelectors: []string{"nav", ".cookie-banner"},
+		RetainImages:    "none",
+		WithIframe:      true,
+		WithShadowDOM:   true,
+	}
+
+	if _, err := client.Read(req); err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+}
+
 func TestClient_Read_POSTMethod(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// 验证 POST 方法
@@ -220,6 +257,83 @@ func TestClient_Search_WithSiteFilter(t *testing.T) {
 	}
 }
 
+func TestClient_Search_QueryParams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := map[string]string{"num": "10", "page": "2", "gl": "us", "hl": "en"}
+		for param, value := range want {
+			if got := r.URL.Query().Get(param); got != value {
+				t.Errorf("Expected %s=%q, got %q", param, value, got)
+			}
+		}
+		_, _ = w.Write([]byte(`{"code":200,"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("https://r.jina.ai/", server.URL+"/", "", 30)
+	req := &SearchRequest{Query: "test", Limit: 10, Page: 2, Country: "us", Language: "en"}
+	if _, err := client.Search(req); err != nil {
+		t.Fatalf("Search() failed: %v", err)
+	}
+}
+
+func TestClient_Search_NoContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Respond-With"); got != "no-content" {
+			t.Errorf("Expected X-Respond-With no-content, got %q", got)
+		}
+		_, _ = w.Write([]byte(`{"data":[{"title":"Go","url":"https://go.dev","description":"desc","content":"ignored"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("https://r.jina.ai/", server.URL+"/", "", 30)
+	resp, err := client.Search(&SearchRequest{Query: "go", ResponseFormat: "markdown", NoContent: true})
+	if err != nil {
+		t.Fatalf("Search() failed: %v", err)
+	}
+	if len(resp.Results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(resp.Results))
+	}
+	if r := resp.Results[0]; r.Title != "Go" || r.Description != "desc" || r.Content != "" {
+		t.Errorf("unexpected result: %+v", r)
+	}
+}
+
+func TestClient_SearchPagesContext(t *testing.T) {
+	// 第 1 页和第 2 页有一个重复结果，第 3 页结果不足一页
+	pages := map[string]string{
+		"1": `{"data":[{"url":"https://a.com"},{"url":"https://b.com"}],"meta":{"usage":{"tokens":10}}}`,
+		"2": `{"data":[{"url":"https://b.com"},{"url":"https://c.com"}],"meta":{"usage":{"tokens":10}}}`,
+		"3": `{"data":[{"url":"https://d.com"}],"meta":{"usage":{"tokens":5}}}`,
+	}
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		requested = append(requested, page)
+		_, _ = w.Write([]byte(pages[page]))
+	}))
+	defer server.Close()
+
+	client := NewClient("https://r.jina.ai/", server.URL+"/", "", 30)
+	resp, err := client.SearchPagesContext(context.Background(), &SearchRequest{Query: "q", Limit: 2}, 5)
+	if err != nil {
+		t.Fatalf("SearchPagesContext() failed: %v", err)
+	}
+
+	var urls []string
+	for _, r := range resp.Results {
+		urls = append(urls, r.URL)
+	}
+	if want := []string{"https://a.com", "https://b.com", "https://c.com", "https://d.com"}; !reflect.DeepEqual(urls, want) {
+		t.Errorf("results = %v, want %v", urls, want)
+	}
+	if want := []string{"1", "2", "3"}; !reflect.DeepEqual(requested, want) {
+		t.Errorf("requested pages = %v, want %v", requested, want)
+	}
+	if resp.Usage.Tokens != 25 {
+		t.Errorf("Expected 25 tokens, got %d", resp.Usage.Tokens)
+	}
+}
+
 func TestClient_Search_HTTPError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -274,19 +388,490 @@ func TestParseSearchResults(t *testing.T) {
 			wantLen: 3,
 		},
 		{
-			name:    "JSON format (not implemented yet)",
+			name:    "empty JSON array",
 			content: "[]",
 			format:  "json",
 			wantLen: 0,
 		},
+		{
+			name:    "JSON envelope",
+			content: `{"code":200,"status":20000,"data":[{"title":"A","url":"https://a.com"},{"title":"B","url":"https://b.com"}]}`,
+			format:  "",
+			wantLen: 2,
+		},
+		{
+			name:    "text format with result markers",
+			content: "[1] Title: A\n[1] URL Source: https://a.com\n[1] Markdown Content:\nbody a\n[2] Title: B\n[2] URL Source: https://b.com",
+			format:  "",
+			wantLen: 2,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			results := parseSearchResults(tt.content, tt.format)
+			results, _ := parseSearchResults(tt.content, tt.format)
 			if len(results) != tt.wantLen {
 				t.Errorf("parseSearchResults() len = %d, want %d", len(results), tt.wantLen)
 			}
 		})
 	}
 }
+
+func TestClient_Search_JSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if accept := r.Header.Get("Accept"); accept != "application/json" {
+			t.Errorf("Expected Accept 'application/json', got %s", accept)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"code": 200,
+			"status": 20000,
+			"data": [
+				{"title": "Go 1.24", "url": "https://go.dev/blog/go1.24", "description": "release", "content": "# Go 1.24", "date": "2025-02-11", "usage": {"tokens": 120}},
+				{"title": "Go News", "url": "https://example.com/news", "content": "news", "usage": {"tokens": 30}}
+			],
+			"meta": {"usage": {"tokens": 150}}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("https://r.jina.ai/", server.URL+"/", "", 30)
+
+	resp, err := client.Search(&SearchRequest{Query: "golang"})
+	if err != nil {
+		t.Fatalf("Search() failed: %v", err)
+	}
+
+	if len(resp.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(resp.Results))
+	}
+	first := resp.Results[0]
+	if first.Title != "Go 1.24" || first.URL != "https://go.dev/blog/go1.24" {
+		t.Errorf("Unexpected first result: %+v", first)
+	}
+	if first.Description != "release" || first.Date != "2025-02-11" {
+		t.Errorf("Expected description and date, got %+v", first)
+	}
+	if first.Usage.Tokens != 120 {
+		t.Errorf("Expected 120 tokens, got %d", first.Usage.Tokens)
+	}
+	if resp.Usage.Tokens != 150 {
+		t.Errorf("Expected total usage 150, got %d", resp.Usage.Tokens)
+	}
+}
+
+func TestParseTextSearchResults(t *testing.T) {
+	content := `[1] Title: First
+[1] URL Source: https://first.com
+[1] Description: first result
+[1] Markdown Content:
+line one
+line two
+
+[2] Title: Second
+[2] URL Source: https://second.com
+[2] Markdown Content:
+second body`
+
+	results := parseTextSearchResults(content)
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if results[0].Title != "First" || results[0].URL != "https://first.com" || results[0].Description != "first result" {
+		t.Errorf("Unexpected first result: %+v", results[0])
+	}
+	if results[0].Content != "line one\nline two" {
+		t.Errorf("Unexpected first content: %q", results[0].Content)
+	}
+	if results[1].Content != "second body" {
+		t.Errorf("Unexpected second content: %q", results[1].Content)
+	}
+}
+
+func TestClient_Read_JSONResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if accept := r.Header.Get("Accept"); accept != "application/json" {
+			t.Errorf("Expected Accept 'application/json', got %s", accept)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"code": 200,
+			"status": 20000,
+			"data": {
+				"title": "Example Domain",
+				"description": "An example page",
+				"url": "https://www.example.com/",
+				"content": "This domain is for use in examples.",
+				"publishedTime": "2025-01-02T03:04:05Z",
+				"images": {"Image 1": "https://example.com/a.png"},
+				"links": {"More information": "https://www.iana.org/domains/example"},
+				"warning": "Target page may be partially rendered",
+				"usage": {"tokens": 42}
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", server.URL+"/", "", 30)
+
+	resp, err := client.Read(&ReadRequest{URL: "https://example.com", JSONResponse: true})
+	if err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+
+	if resp.URL != "https://example.com" {
+		t.Errorf("Expected URL 'https://example.com', got %s", resp.URL)
+	}
+	if resp.FinalURL != "https://www.example.com/" {
+		t.Errorf("Expected FinalURL 'https://www.example.com/', got %s", resp.FinalURL)
+	}
+	if resp.Title != "Example Domain" || resp.Description != "An example page" {
+		t.Errorf("Unexpected title/description: %q / %q", resp.Title, resp.Description)
+	}
+	if resp.Content != "This domain is for use in examples." {
+		t.Errorf("Unexpected content: %q", resp.Content)
+	}
+	if resp.PublishedTime != "2025-01-02T03:04:05Z" {
+		t.Errorf("Unexpected publishedTime: %q", resp.PublishedTime)
+	}
+	if len(resp.Images) != 1 || resp.Images[0] != (Image{Alt: "Image 1", URL: "https://example.com/a.png"}) {
+		t.Errorf("Unexpected images: %v", resp.Images)
+	}
+	if len(resp.Links) != 1 || resp.Links[0] != (Link{Text: "More information", URL: "https://www.iana.org/domains/example"}) {
+		t.Errorf("Unexpected links: %v", resp.Links)
+	}
+	if resp.Warning == "" {
+		t.Error("Expected warning to be set")
+	}
+	if resp.Usage.Tokens != 42 {
+		t.Errorf("Expected 42 tokens, got %d", resp.Usage.Tokens)
+	}
+}
+
+func TestClient_Read_JSONResponseFormats(t *testing.T) {
+	tests := []struct {
+		format string
+		data   string
+		want   string
+	}{
+		{"html", `{"title": "T", "html": "<html><body>hi</body></html>"}`, "<html><body>hi</body></html>"},
+		{"text", `{"title": "T", "text": "hi there"}`, "hi there"},
+		{"screenshot", `{"screenshotUrl": "https://cdn.example.com/a.png"}`, "https://cdn.example.com/a.png"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("X-Respond-With"); got != tt.format {
+					t.Errorf("X-Respond-With = %q, want %q", got, tt.format)
+				}
+				_, _ = w.Write([]byte(`{"code": 200, "status": 20000, "data": ` + tt.data + `}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL+"/", server.URL+"/", "", 30)
+			resp, err := client.Read(&ReadRequest{URL: "https://example.com", ResponseFormat: tt.format, JSONResponse: true})
+			if err != nil {
+				t.Fatalf("Read() failed: %v", err)
+			}
+			if resp.Content != tt.want {
+				t.Errorf("Content = %q, want %q", resp.Content, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_Read_JSONResponseFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("# Plain\n\nnot json"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", server.URL+"/", "", 30)
+
+	resp, err := client.Read(&ReadRequest{URL: "https://example.com", JSONResponse: true})
+	if err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	if resp.Content != "# Plain\n\nnot json" {
+		t.Errorf("Expected raw content fallback, got %q", resp.Content)
+	}
+}
+
+func TestClient_ReadContext_Canceled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(server.URL+"/", server.URL+"/", "", 30)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err := client.ReadContext(ctx, &ReadRequest{URL: "https://example.com"})
+	if got := ErrorCodeOf(err); got != CodeInterrupted {
+		t.Errorf("Expected code %s, got %s (%v)", CodeInterrupted, got, err)
+	}
+}
+
+func TestClient_SearchContext_Deadline(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient("https://r.jina.ai/", server.URL+"/", "", 30)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.SearchContext(ctx, &SearchRequest{Query: "test"})
+	if got := ErrorCodeOf(err); got != CodeTimeout {
+		t.Errorf("Expected code %s, got %s (%v)", CodeTimeout, got, err)
+	}
+}
+
+func TestClient_ReadContext_CanceledDuringRetryWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", server.URL+"/", "", 30)
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.ReadContext(ctx, &ReadRequest{URL: "https://example.com"})
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected retry wait to stop with context, took %v", elapsed)
+	}
+}
+
+// memoryCache 测试用的内存缓存
+type memoryCache struct {
+	entries map[string][]byte
+}
+
+func (m *memoryCache) Get(key string) ([]byte, bool) {
+	body, ok := m.entries[key]
+	return body, ok
+}
+
+func (m *memoryCache) Put(key, url string, body []byte) error {
+	m.entries[key] = body
+	return nil
+}
+
+func TestClient_ReadContext_Cache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte("content " + r.Header.Get("X-Target-Selector")))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, server.URL, "key", 30)
+	cache := &memoryCache{entries: make(map[string][]byte)}
+	client.SetCache(cache)
+
+	read := func(req *ReadRequest) string {
+		t.Helper()
+		resp, err := client.ReadContext(context.Background(), req)
+		if err != nil {
+			t.Fatalf("ReadContext() failed: %v", err)
+		}
+		return resp.Content
+	}
+
+	read(&ReadRequest{URL: "https://example.com"})
+	if got := read(&ReadRequest{URL: "https://example.com"}); got != "content " || requests != 1 {
+		t.Errorf("second read = %q after %d requests, want cache hit", got, requests)
+	}
+
+	// 影响结果的请求头不同，缓存键不同
+	if got := read(&ReadRequest{URL: "https://example.com", TargetSelector: "#main"}); got != "content #main" || requests != 2 {
+		t.Errorf("read with selector = %q after %d requests", got, requests)
+	}
+
+	// NoCache 跳过缓存查找并刷新缓存
+	read(&ReadRequest{URL: "https://example.com", NoCache: true})
+	if requests != 3 || len(cache.entries) != 2 {
+		t.Errorf("NoCache read: %d requests, %d cache entries; want 3, 2", requests, len(cache.entries))
+	}
+}
+
+func TestClient_SearchContext_CacheSkipsErrors(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, server.URL, "", 30)
+	cache := &memoryCache{entries: make(map[string][]byte)}
+	client.SetCache(cache)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.SearchContext(context.Background(), &SearchRequest{Query: "q"}); err == nil {
+			t.Fatal("SearchContext() expected error")
+		}
+	}
+	if requests != 2 || len(cache.entries) != 0 {
+		t.Errorf("%d requests, %d cache entries; want 2, 0", requests, len(cache.entries))
+	}
+}
+
+func TestClient_LimitHeaders(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		_, _ = w.Write([]byte(`{"code":200,"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, server.URL, "", 30)
+
+	check := func(name string, want map[string]string) {
+		t.Helper()
+		for header, value := range want {
+			if got.Get(header) != value {
+				t.Errorf("%s: %s = %q, want %q", name, header, got.Get(header), value)
+			}
+		}
+	}
+
+	if _, err := client.Read(&ReadRequest{URL: "https://example.com", Timeout: 15, CacheTolerance: "0", TokenBudget: 2000}); err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	check("read", map[string]string{"X-Timeout": "15", "X-Cache-Tolerance": "0", "X-Token-Budget": "2000"})
+
+	if _, err := client.Search(&SearchRequest{Query: "q", Timeout: 20, TokenBudget: 500}); err != nil {
+		t.Fatalf("Search() failed: %v", err)
+	}
+	check("search", map[string]string{"X-Timeout": "20", "X-Cache-Tolerance": "", "X-Token-Budget": "500"})
+
+	// 零值不发送
+	if _, err := client.Read(&ReadRequest{URL: "https://example.com"}); err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	check("read defaults", map[string]string{"X-Timeout": "", "X-Cache-Tolerance": "", "X-Token-Budget": ""})
+}
+
+func TestClient_Read_Document(t *testing.T) {
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected JSON POST, got %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		got = nil
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"code":200,"data":{"title":"Report","content":"# Report"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, server.URL, "", 30)
+
+	resp, err := client.Read(&ReadRequest{URL: "https://example.com/docs/", HTML: "<a href=\"x\">x</a>", JSONResponse: true})
+	if err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	if resp.Title != "Report" {
+		t.Errorf("Expected title Report, got %s", resp.Title)
+	}
+	if got["url"] != "https://example.com/docs/" || got["html"] != "<a href=\"x\">x</a>" {
+		t.Errorf("unexpected html payload: %v", got)
+	}
+
+	if _, err := client.Read(&ReadRequest{PDF: []byte("%PDF-1.7")}); err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	if _, ok := got["url"]; ok || got["pdf"] != "JVBERi0xLjc=" {
+		t.Errorf("unexpected pdf payload: %v", got)
+	}
+}
+
+func TestClient_Read_FetchOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := map[string]string{
+			"X-Engine":     "browser",
+			"X-Locale":     "de-DE",
+			"X-User-Agent": "Mozilla/5.0 Test",
+			"X-Referer":    "https://google.com/",
+		}
+		for header, value := range want {
+			if got := r.Header.Get(header); got != value {
+				t.Errorf("Expected %s %q, got %q", header, value, got)
+			}
+		}
+		// 目标页面的 User-Agent 不影响请求 Reader 本身的 User-Agent
+		if ua := r.Header.Get("User-Agent"); !strings.Contains(ua, "jina-cli") {
+			t.Errorf("Expected User-Agent to contain jina-cli, got %s", ua)
+		}
+		_, _ = w.Write([]byte("content"))
+	}))
+	defer server.Close()
+
+	var log strings.Builder
+	client := NewClient(server.URL, server.URL, "", 30)
+	client.SetLogger(&log)
+
+	req := &ReadRequest{
+		URL:       "https://example.com",
+		Engine:    "browser",
+		Locale:    "de-DE",
+		UserAgent: "Mozilla/5.0 Test",
+		Referer:   "https://google.com/",
+	}
+	if _, err := client.Read(req); err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	if !strings.Contains(log.String(), "engine=browser, locale=de-DE, user-agent=Mozilla/5.0 Test, referer=https://google.com/") {
+		t.Errorf("verbose log missing fetch options: %q", log.String())
+	}
+
+	// 抓取选项参与缓存键计算
+	keyFor := func(r *ReadRequest) string {
+		httpReq, err := client.newReadRequest(context.Background(), r)
+		if err != nil {
+			t.Fatalf("newReadRequest() failed: %v", err)
+		}
+		key, err := cacheKey(httpReq)
+		if err != nil {
+			t.Fatalf("cacheKey() failed: %v", err)
+		}
+		return key
+	}
+	other := *req
+	other.Locale = "en-US"
+	if keyFor(req) == keyFor(&other) {
+		t.Error("cache key should differ by locale")
+	}
+}
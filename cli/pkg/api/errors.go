@@ -0,0 +1,227 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// 稳定错误码，输出到错误响应的 code 字段。
+//
+// 错误码与进程退出码一一对应，脚本可以据此区分失败类型：
+//
+//	INVALID_INPUT         2  参数或输入无效
+//	AUTH_FAILED           3  API Key 缺失或无效（401/403）
+//	INSUFFICIENT_BALANCE  4  账户余额不足（402）
+//	RATE_LIMITED          5  触发速率限制（429）
+//	TIMEOUT               6  请求超时（客户端或服务端）
+//	TARGET_UNREACHABLE    7  目标页面无法访问
+//	SERVER_ERROR          8  Jina 服务端错误（5xx）
+//	NETWORK_ERROR         9  无法连接 Jina 服务
+//	INTERNAL_ERROR        1  本地错误（配置、文件读写等）
+//	INTERRUPTED         130  被用户中断（Ctrl-C / SIGTERM）
+const (
+	CodeInvalidInput        = "INVALID_INPUT"
+	CodeAuthFailed          = "AUTH_FAILED"
+	CodeInsufficientBalance = "INSUFFICIENT_BALANCE"
+	CodeRateLimited         = "RATE_LIMITED"
+	CodeTimeout             = "TIMEOUT"
+	CodeTargetUnreachable   = "TARGET_UNREACHABLE"
+	CodeServerError         = "SERVER_ERROR"
+	CodeNetworkError        = "NETWORK_ERROR"
+	CodeInternal            = "INTERNAL_ERROR"
+	CodeInterrupted         = "INTERRUPTED"
+)
+
+// 进程退出码
+const (
+	ExitGeneral             = 1
+	ExitInvalidInput        = 2
+	ExitAuthFailed          = 3
+	ExitInsufficientBalance = 4
+	ExitRateLimited         = 5
+	ExitTimeout             = 6
+	ExitTargetUnreachable   = 7
+	ExitServerError         = 8
+	ExitNetworkError        = 9
+	ExitInterrupted         = 130
+)
+
+// exitCodes 错误码到退出码的映射
+var exitCodes = map[string]int{
+	CodeInvalidInput:        ExitInvalidInput,
+	CodeAuthFailed:          ExitAuthFailed,
+	CodeInsufficientBalance: ExitInsufficientBalance,
+	CodeRateLimited:         ExitRateLimited,
+	CodeTimeout:             ExitTimeout,
+	CodeTargetUnreachable:   ExitTargetUnreachable,
+	CodeServerError:         ExitServerError,
+	CodeNetworkError:        ExitNetworkError,
+	CodeInternal:            ExitGeneral,
+	CodeInterrupted:         ExitInterrupted,
+}
+
+// ExitCodeFor 返回错误码对应的进程退出码，未知错误码返回 ExitGeneral
+func ExitCodeFor(code string) int {
+	if exit, ok := exitCodes[code]; ok {
+		return exit
+	}
+	return ExitGeneral
+}
+
+// Error API 请求错误
+type Error struct {
+	Code       string // 稳定错误码（CodeXxx）
+	StatusCode int    // HTTP 状态码，传输错误时为 0
+	JinaStatus int    // Jina 响应中的细分状态码（如 42206）
+	Name       string // Jina 错误名（如 AssertionFailureError）
+	Message    string // 错误描述
+	Retryable  bool   // 是否为可重试的临时错误
+	URL        string // 请求 URL
+	Err        error  // 底层错误
+}
+
+// Error 实现 error 接口
+func (e *Error) Error() string {
+	switch {
+	case e.StatusCode != 0 && e.Name != "":
+		return fmt.Sprintf("HTTP 错误: %d, %s: %s", e.StatusCode, e.Name, e.Message)
+	case e.StatusCode != 0:
+		return fmt.Sprintf("HTTP 错误: %d, 响应: %s", e.StatusCode, e.Message)
+	case e.Err != nil && e.Message != "":
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	case e.Err != nil:
+		return e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap 返回底层错误
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode 返回稳定错误码
+func (e *Error) ErrorCode() string {
+	return e.Code
+}
+
+// ExitCode 返回进程退出码
+func (e *Error) ExitCode() int {
+	return ExitCodeFor(e.Code)
+}
+
+// NewError 用指定错误码包装错误，已携带错误码的错误原样返回
+func NewError(code string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return err
+	}
+	return &Error{Code: code, Err: err}
+}
+
+// ErrorCodeOf 返回错误的稳定错误码，无法识别时返回 CodeInternal
+func ErrorCodeOf(err error) string {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return CodeInternal
+}
+
+// jinaErrorBody Jina API 的错误响应结构
+type jinaErrorBody struct {
+	Code            int    `json:"code"`
+	Status          int    `json:"status"`
+	Name            string `json:"name"`
+	Message         string `json:"message"`
+	ReadableMessage string `json:"readableMessage"`
+}
+
+// newHTTPError 根据非 200 响应构建错误
+func newHTTPError(method, requestURL string, status int, body []byte) *Error {
+	e := &Error{
+		StatusCode: status,
+		Message:    strings.TrimSpace(string(body)),
+		Retryable:  shouldRetryStatus(method, status),
+		URL:        requestURL,
+	}
+
+	var jb jinaErrorBody
+	if err := json.Unmarshal(body, &jb); err == nil && (jb.Name != "" || jb.Message != "") {
+		e.Name = jb.Name
+		e.JinaStatus = jb.Status
+		e.Message = jb.Message
+		if e.Message == "" {
+			e.Message = jb.ReadableMessage
+		}
+	}
+
+	e.Code = classifyHTTPError(status, e.Name)
+	return e
+}
+
+// classifyHTTPError 将 HTTP 状态码和 Jina 错误名映射为稳定错误码
+func classifyHTTPError(status int, name string) string {
+	switch {
+	case strings.Contains(name, "Timeout"):
+		return CodeTimeout
+	case name == "ParamValidationError":
+		return CodeInvalidInput
+	}
+
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return CodeAuthFailed
+	case http.StatusPaymentRequired:
+		return CodeInsufficientBalance
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout, 524:
+		return CodeTimeout
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge:
+		return CodeInvalidInput
+	case http.StatusNotFound, http.StatusGone, http.StatusUnprocessableEntity, http.StatusUnavailableForLegalReasons:
+		return CodeTargetUnreachable
+	}
+	if status >= 500 {
+		return CodeServerError
+	}
+	return CodeInternal
+}
+
+// newTransportError 根据传输层错误构建错误
+func newTransportError(method, requestURL string, err error) *Error {
+	e := &Error{
+		Code:      CodeNetworkError,
+		Message:   "请求失败",
+		Retryable: isIdempotent(method),
+		URL:       requestURL,
+		Err:       err,
+	}
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		e.Code = CodeInterrupted
+		e.Message = "请求已取消"
+		e.Retryable = false
+	case errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()):
+		e.Code = CodeTimeout
+	}
+	return e
+}
+
+// newContextError 根据 context 结束原因构建错误
+func newContextError(requestURL string, err error) *Error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &Error{Code: CodeTimeout, Message: "请求超时", URL: requestURL, Err: err}
+	}
+	return &Error{Code: CodeInterrupted, Message: "请求已取消", URL: requestURL, Err: err}
+}
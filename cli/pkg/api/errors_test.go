@@ -0,0 +1,123 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClassifyHTTPError(t *testing.T) {
+	tests := []struct {
+		status int
+		name   string
+		want   string
+	}{
+		{http.StatusUnauthorized, "AuthenticationFailedError", CodeAuthFailed},
+		{http.StatusForbidden, "", CodeAuthFailed},
+		{http.StatusPaymentRequired, "InsufficientBalanceError", CodeInsufficientBalance},
+		{http.StatusTooManyRequests, "RateLimitTriggeredError", CodeRateLimited},
+		{http.StatusBadRequest, "", CodeInvalidInput},
+		{http.StatusUnprocessableEntity, "ParamValidationError", CodeInvalidInput},
+		{http.StatusUnprocessableEntity, "AssertionFailureError", CodeTargetUnreachable},
+		{http.StatusUnprocessableEntity, "TimeoutError", CodeTimeout},
+		{http.StatusGatewayTimeout, "", CodeTimeout},
+		{http.StatusNotFound, "", CodeTargetUnreachable},
+		{http.StatusInternalServerError, "", CodeServerError},
+		{http.StatusBadGateway, "", CodeServerError},
+		{http.StatusTeapot, "", CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d_%s", tt.status, tt.name), func(t *testing.T) {
+			if got := classifyHTTPError(tt.status, tt.name); got != tt.want {
+				t.Errorf("classifyHTTPError(%d, %q) = %s, want %s", tt.status, tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCodeFor(t *testing.T) {
+	seen := map[int]string{}
+	for code, exit := range exitCodes {
+		if exit == ExitGeneral {
+			continue
+		}
+		if other, ok := seen[exit]; ok {
+			t.Errorf("Exit code %d shared by %s and %s", exit, code, other)
+		}
+		seen[exit] = code
+	}
+	if got := ExitCodeFor("SOMETHING_ELSE"); got != ExitGeneral {
+		t.Errorf("ExitCodeFor(unknown) = %d, want %d", got, ExitGeneral)
+	}
+	if got := ExitCodeFor(CodeRateLimited); got != ExitRateLimited {
+		t.Errorf("ExitCodeFor(RATE_LIMITED) = %d, want %d", got, ExitRateLimited)
+	}
+}
+
+func TestClient_Read_JinaError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"data":null,"code":422,"name":"AssertionFailureError","status":42206,"message":"Failed to goto https://nowhere.invalid: net::ERR_NAME_NOT_RESOLVED","readableMessage":"AssertionFailureError: Failed to goto"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", server.URL+"/", "", 30)
+
+	_, err := client.Read(&ReadRequest{URL: "https://nowhere.invalid"})
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *Error, got %T: %v", err, err)
+	}
+	if apiErr.Code != CodeTargetUnreachable {
+		t.Errorf("Expected code %s, got %s", CodeTargetUnreachable, apiErr.Code)
+	}
+	if apiErr.StatusCode != 422 || apiErr.JinaStatus != 42206 || apiErr.Name != "AssertionFailureError" {
+		t.Errorf("Unexpected error fields: %+v", apiErr)
+	}
+	if apiErr.Retryable {
+		t.Error("Expected 422 to be non-retryable")
+	}
+	if apiErr.URL == "" {
+		t.Error("Expected request URL to be recorded")
+	}
+	if apiErr.ExitCode() != ExitTargetUnreachable {
+		t.Errorf("Expected exit code %d, got %d", ExitTargetUnreachable, apiErr.ExitCode())
+	}
+}
+
+func TestClient_Read_NetworkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serverURL := server.URL
+	server.Close()
+
+	client := NewClient(serverURL+"/", serverURL+"/", "", 30)
+
+	_, err := client.Read(&ReadRequest{URL: "https://example.com"})
+	if got := ErrorCodeOf(err); got != CodeNetworkError {
+		t.Errorf("Expected code %s, got %s (%v)", CodeNetworkError, got, err)
+	}
+}
+
+func TestNewError(t *testing.T) {
+	if NewError(CodeInvalidInput, nil) != nil {
+		t.Error("Expected nil for nil error")
+	}
+
+	err := NewError(CodeInvalidInput, errors.New("bad flag"))
+	if ErrorCodeOf(err) != CodeInvalidInput {
+		t.Errorf("Expected code %s, got %s", CodeInvalidInput, ErrorCodeOf(err))
+	}
+	if err.Error() != "bad flag" {
+		t.Errorf("Expected message 'bad flag', got %q", err.Error())
+	}
+
+	// 已有错误码的错误不会被覆盖
+	rateLimited := &Error{Code: CodeRateLimited, StatusCode: 429, Message: "slow down"}
+	if ErrorCodeOf(NewError(CodeInvalidInput, rateLimited)) != CodeRateLimited {
+		t.Error("Expected existing code to be preserved")
+	}
+}
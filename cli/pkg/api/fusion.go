@@ -0,0 +1,101 @@
+package api
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// RRFConstant 倒数排名融合（Reciprocal Rank Fusion）的平滑常数 k
+const RRFConstant = 60
+
+// FusedResult 多个查询的搜索结果合并后的单个结果
+type FusedResult struct {
+	SearchResult
+	Score   float64  // 各查询中 1/(k+排名) 之和
+	Queries []string // 命中该结果的查询，按查询顺序
+}
+
+// FuseSearchResults 用倒数排名融合合并多个查询的搜索结果
+//
+// 结果按 NormalizeURL 去重，保留排名最靠前的一条的字段；得分相同时按首次出现的顺序排列。
+// 没有 URL 的结果无法去重，直接以各自的得分参与排序。
+func FuseSearchResults(responses []*SearchResponse) []FusedResult {
+	var fused []*FusedResult
+	byURL := make(map[string]*FusedResult)
+	bestRank := make(map[*FusedResult]int)
+
+	for _, resp := range responses {
+		if resp == nil {
+			continue
+		}
+		for i, result := range resp.Results {
+			rank := i + 1
+			score := 1 / float64(RRFConstant+rank)
+
+			key := NormalizeURL(result.URL)
+			f, ok := byURL[key]
+			if !ok || key == "" {
+				f = &FusedResult{SearchResult: result}
+				fused = append(fused, f)
+				bestRank[f] = rank
+				if key != "" {
+					byURL[key] = f
+				}
+			} else if rank < bestRank[f] {
+				f.SearchResult = result
+				bestRank[f] = rank
+			}
+			f.Score += score
+			if len(f.Queries) == 0 || f.Queries[len(f.Queries)-1] != resp.Query {
+				f.Queries = append(f.Queries, resp.Query)
+			}
+		}
+	}
+
+	sort.SliceStable(fused, func(i, j int) bool {
+		return fused[i].Score > fused[j].Score
+	})
+	results := make([]FusedResult, len(fused))
+	for i, f := range fused {
+		results[i] = *f
+	}
+	return results
+}
+
+// trackingParams 去重时忽略的跟踪参数前缀
+var trackingParams = []string{"utm_", "fbclid", "gclid"}
+
+// NormalizeURL 返回用于判断两个 URL 是否指向同一页面的键，不能用于发送请求
+//
+// 忽略协议、大小写不敏感的主机名、www. 前缀、默认端口、片段、末尾斜杠和跟踪参数，
+// 其余查询参数按名称排序。无法解析时返回去除首尾空白的原始字符串。
+func NormalizeURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+
+	host := strings.ToLower(u.Hostname())
+	host = strings.TrimPrefix(host, "www.")
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	query := u.Query()
+	for name := range query {
+		for _, prefix := range trackingParams {
+			if strings.HasPrefix(strings.ToLower(name), prefix) {
+				query.Del(name)
+				break
+			}
+		}
+	}
+
+	key := host + strings.TrimRight(u.EscapedPath(), "/")
+	if encoded := query.Encode(); encoded != "" {
+		key += "?" + encoded
+	}
+	return key
+}
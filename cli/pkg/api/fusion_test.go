@@ -0,0 +1,70 @@
+package api
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		a, b string
+		same bool
+	}{
+		{"https://www.Example.com/docs/", "http://example.com/docs", true},
+		{"https://example.com:443/a#section", "https://example.com/a", true},
+		{"https://example.com/a?b=2&a=1", "https://example.com/a?a=1&b=2", true},
+		{"https://example.com/a?utm_source=x&id=1", "https://example.com/a?id=1", true},
+		{"https://example.com/a?id=1", "https://example.com/a?id=2", false},
+		{"https://example.com:8080/a", "https://example.com/a", false},
+		{"https://example.com/A", "https://example.com/a", false},
+	}
+
+	for _, tt := range tests {
+		got := NormalizeURL(tt.a) == NormalizeURL(tt.b)
+		if got != tt.same {
+			t.Errorf("NormalizeURL(%q) == NormalizeURL(%q) is %t, want %t (%q, %q)",
+				tt.a, tt.b, got, tt.same, NormalizeURL(tt.a), NormalizeURL(tt.b))
+		}
+	}
+
+	if got := NormalizeURL("  not a url "); got != "not a url" {
+		t.Errorf("NormalizeURL() = %q, want raw string", got)
+	}
+}
+
+func TestFuseSearchResults(t *testing.T) {
+	responses := []*SearchResponse{
+		{Query: "a", Results: []SearchResult{
+			{Title: "X", URL: "https://x.com/"},
+			{Title: "Y", URL: "https://y.com"},
+			{Title: "Z", URL: "https://z.com"},
+		}},
+		nil, // 失败的查询
+		{Query: "b", Results: []SearchResult{
+			{Title: "Y2", URL: "https://www.y.com/"},
+			{Title: "W", URL: "https://w.com"},
+			{Title: "X2", URL: "http://x.com"},
+		}},
+	}
+
+	fused := FuseSearchResults(responses)
+
+	var titles []string
+	for _, f := range fused {
+		titles = append(titles, f.Title)
+	}
+	// Y: 1/62+1/61 > X: 1/61+1/63 > W: 1/62 > Z: 1/63
+	if want := []string{"Y2", "X", "W", "Z"}; !reflect.DeepEqual(titles, want) {
+		t.Errorf("titles = %v, want %v", titles, want)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(fused[0].Queries, want) {
+		t.Errorf("queries = %v, want %v", fused[0].Queries, want)
+	}
+	if want := 1.0/61 + 1.0/62; math.Abs(fused[0].Score-want) > 1e-12 {
+		t.Errorf("score = %v, want %v", fused[0].Score, want)
+	}
+	if want := []string{"b"}; !reflect.DeepEqual(fused[2].Queries, want) {
+		t.Errorf("queries = %v, want %v", fused[2].Queries, want)
+	}
+}
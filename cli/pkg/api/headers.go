@@ -0,0 +1,33 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/geekjourneyx/jina-cli/cli/pkg/httpheader"
+)
+
+// quietHeaders 详细日志中不显示的通用请求头
+var quietHeaders = map[string]bool{
+	"Accept":       true,
+	"Content-Type": true,
+	"User-Agent":   true,
+}
+
+// formatHeaders 将请求头格式化为详细日志，敏感值掩码显示
+func formatHeaders(header http.Header) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		if !quietHeaders[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + ": " + httpheader.Mask(name, strings.Join(header[name], ", "))
+	}
+	return strings.Join(parts, "; ")
+}
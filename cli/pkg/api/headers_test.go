@@ -0,0 +1,18 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestFormatHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set("Accept", "application/json")
+	h.Set("Authorization", "Bearer jina_1234567890")
+	h.Set("X-Locale", "en-US")
+
+	want := "Authorization: Bear***7890; X-Locale: en-US"
+	if got := formatHeaders(h); got != want {
+		t.Errorf("formatHeaders() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries 默认最大重试次数（不含首次请求）
+	DefaultMaxRetries = 2
+	// DefaultRetryBaseDelay 默认首次重试等待时间
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	// DefaultRetryMaxDelay 默认单次等待时间上限
+	DefaultRetryMaxDelay = 60 * time.Second
+)
+
+// RetryPolicy 重试策略
+//
+// 第 n 次重试前等待 BaseDelay * 2^(n-1)（带随机抖动，不超过 MaxDelay）。
+// 如果服务端返回 Retry-After，则至少等待该时长。
+type RetryPolicy struct {
+	MaxRetries int           // 最大重试次数，0 表示不重试
+	BaseDelay  time.Duration // 首次重试的基础等待时间
+	MaxDelay   time.Duration // 单次等待时间上限
+}
+
+// DefaultRetryPolicy 返回默认重试策略
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: DefaultMaxRetries,
+		BaseDelay:  DefaultRetryBaseDelay,
+		MaxDelay:   DefaultRetryMaxDelay,
+	}
+}
+
+// backoff 计算第 retry 次重试（从 1 开始）前的等待时间
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+	delay := p.BaseDelay
+	for i := 1; i < retry && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	// 抖动：在 [delay/2, delay] 区间内随机，避免批量请求同时重试
+	half := delay / 2
+	// #nosec G404 -- 抖动不需要加密安全的随机数
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// wait 计算重试前的等待时间，Retry-After 优先
+func (p RetryPolicy) wait(retry int, retryAfter time.Duration) time.Duration {
+	delay := p.backoff(retry)
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// isIdempotent 判断请求方法是否幂等
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// shouldRetryStatus 判断 HTTP 状态码是否可以重试
+//
+// 非幂等请求只在服务端明确表示未处理请求时（429、503）重试。
+func shouldRetryStatus(method string, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusRequestTimeout, http.StatusTooEarly,
+		http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+	return false
+}
+
+// parseRetryAfter 解析 Retry-After 头（秒数或 HTTP 日期）
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		retry int
+		min   time.Duration
+		max   time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			got := policy.backoff(tt.retry)
+			if got < tt.min || got > tt.max {
+				t.Fatalf("backoff(%d) = %v, want in [%v, %v]", tt.retry, got, tt.min, tt.max)
+			}
+		}
+	}
+}
+
+func TestRetryPolicy_WaitHonorsRetryAfter(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 1, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Second}
+
+	if got := policy.wait(1, 3*time.Second); got != 3*time.Second {
+		t.Errorf("wait() = %v, want 3s", got)
+	}
+	// Retry-After 超过上限时截断
+	if got := policy.wait(1, time.Minute); got != 10*time.Second {
+		t.Errorf("wait() = %v, want 10s", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{"empty", "", 0},
+		{"seconds", "5", 5 * time.Second},
+		{"negative", "-1", 0},
+		{"http date", now.Add(10 * time.Second).Format(http.TimeFormat), 10 * time.Second},
+		{"past date", now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"invalid", "soon", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.value, now); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShouldRetryStatus(t *testing.T) {
+	tests := []struct {
+		method string
+		status int
+		want   bool
+	}{
+		{"GET", http.StatusTooManyRequests, true},
+		{"GET", http.StatusBadGateway, true},
+		{"GET", http.StatusServiceUnavailable, true},
+		{"GET", http.StatusNotFound, false},
+		{"GET", http.StatusUnauthorized, false},
+		{"POST", http.StatusBadGateway, false},
+		{"POST", http.StatusTooManyRequests, true},
+		{"POST", http.StatusServiceUnavailable, true},
+	}
+
+	for _, tt := range tests {
+		if got := shouldRetryStatus(tt.method, tt.status); got != tt.want {
+			t.Errorf("shouldRetryStatus(%s, %d) = %v, want %v", tt.method, tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestClient_Read_RetriesTransientErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		switch n {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("content"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", server.URL+"/", "", 30)
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond})
+
+	resp, err := client.Read(&ReadRequest{URL: "https://example.com"})
+	if err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	if resp.Content != "content" {
+		t.Errorf("Expected content 'content', got %q", resp.Content)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("Expected 3 attempts, got %d", got)
+	}
+}
+
+func TestClient_Read_RetryExhausted(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", server.URL+"/", "", 30)
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 1, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond})
+
+	if _, err := client.Read(&ReadRequest{URL: "https://example.com"}); err == nil {
+		t.Fatal("Expected error after retries are exhausted, got nil")
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("Expected 2 attempts, got %d", got)
+	}
+}
+
+func TestClient_Read_POSTNotRetriedOnBadGateway(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, server.URL, "", 30)
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond})
+
+	if _, err := client.Read(&ReadRequest{URL: "https://example.com/#/route", PostMethod: true}); err == nil {
+		t.Fatal("Expected error for 502 response, got nil")
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected 1 attempt for non-idempotent request, got %d", got)
+	}
+}
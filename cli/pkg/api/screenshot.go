@@ -0,0 +1,66 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"  // 注册 GIF 解码器，用于读取截图尺寸
+	_ "image/jpeg" // 注册 JPEG 解码器
+	_ "image/png"  // 注册 PNG 解码器
+	"net/http"
+	"strings"
+)
+
+// ScreenshotFormats 返回截图 URL 而非正文的响应格式
+var ScreenshotFormats = []string{"screenshot", "pageshot"}
+
+// Screenshot 下载的截图
+type Screenshot struct {
+	Data        []byte
+	ContentType string // 根据内容识别的 MIME 类型，如 image/png
+	Width       int    // 无法识别尺寸时为 0
+	Height      int
+}
+
+// imageExtensions 图片 MIME 类型对应的文件扩展名
+var imageExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// Extension 返回截图格式对应的文件扩展名，未知格式时为 .png
+func (s *Screenshot) Extension() string {
+	if ext, ok := imageExtensions[s.ContentType]; ok {
+		return ext
+	}
+	return ".png"
+}
+
+// DownloadScreenshotContext 下载 screenshot/pageshot 格式返回的图片
+//
+// 下载内容必须是图片，否则返回 SERVER_ERROR（例如存储返回了错误页面）。
+func (c *Client) DownloadScreenshotContext(ctx context.Context, imageURL string) (*Screenshot, error) {
+	imageURL = strings.TrimSpace(imageURL)
+	if !strings.HasPrefix(imageURL, "http://") && !strings.HasPrefix(imageURL, "https://") {
+		return nil, &Error{Code: CodeServerError, Message: "响应中没有截图 URL"}
+	}
+
+	data, err := c.DownloadContext(ctx, imageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := http.DetectContentType(data)
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, &Error{Code: CodeServerError, Message: fmt.Sprintf("截图下载结果不是图片（%s）: %s", contentType, imageURL)}
+	}
+
+	shot := &Screenshot{Data: data, ContentType: contentType}
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		shot.Width, shot.Height = cfg.Width, cfg.Height
+	}
+	return shot, nil
+}
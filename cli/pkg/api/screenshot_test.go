@@ -0,0 +1,56 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_DownloadScreenshotContext(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 32, 16))); err != nil {
+		t.Fatal(err)
+	}
+	pngData := buf.Bytes()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Error("Authorization should not be sent to image storage")
+		}
+		if r.URL.Path == "/shot.png" {
+			_, _ = w.Write(pngData)
+			return
+		}
+		_, _ = w.Write([]byte("<html>not found</html>"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, server.URL, "test-key", 30)
+
+	shot, err := client.DownloadScreenshotContext(context.Background(), server.URL+"/shot.png")
+	if err != nil {
+		t.Fatalf("DownloadScreenshotContext() failed: %v", err)
+	}
+	if shot.ContentType != "image/png" || shot.Width != 32 || shot.Height != 16 || shot.Extension() != ".png" {
+		t.Errorf("unexpected screenshot: type=%s %dx%d", shot.ContentType, shot.Width, shot.Height)
+	}
+	if !bytes.Equal(shot.Data, pngData) {
+		t.Error("screenshot data mismatch")
+	}
+
+	// 不是图片
+	_, err = client.DownloadScreenshotContext(context.Background(), server.URL+"/error")
+	if ErrorCodeOf(err) != CodeServerError {
+		t.Errorf("Expected SERVER_ERROR for non-image, got %v", err)
+	}
+
+	// 响应中没有截图 URL
+	_, err = client.DownloadScreenshotContext(context.Background(), "# Markdown body")
+	if ErrorCodeOf(err) != CodeServerError {
+		t.Errorf("Expected SERVER_ERROR for missing URL, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Link 页面中的链接
+type Link struct {
+	Text string `json:"text"`
+	URL  string `json:"url"`
+}
+
+// Image 页面中的图片
+type Image struct {
+	Alt string `json:"alt"`
+	URL string `json:"url"`
+}
+
+// pair JSON 响应中的「说明 -> URL」条目
+type pair struct {
+	Key   string
+	Value string
+}
+
+// pairList 按原始顺序解析的「说明 -> URL」列表
+//
+// Read API 通常返回对象 {"文本": "URL"}，链接摘要为 all 模式时返回数组 [["文本", "URL"]]，
+// 两种格式都支持。对象按文档顺序解析，不经过 map。
+type pairList []pair
+
+// UnmarshalJSON 解析对象或二元数组形式的列表
+func (p *pairList) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0 || bytes.Equal(data, []byte("null")):
+		*p = nil
+		return nil
+	case data[0] == '[':
+		var items [][]string
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		list := make(pairList, 0, len(items))
+		for _, item := range items {
+			if len(item) >= 2 {
+				list = append(list, pair{Key: item[0], Value: item[1]})
+			}
+		}
+		*p = list
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return fmt.Errorf("无效的列表格式")
+	}
+	var list pairList
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		var value string
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		list = append(list, pair{Key: key, Value: value})
+	}
+	*p = list
+	return nil
+}
+
+// links 转换为链接列表
+func (p pairList) links() []Link {
+	if len(p) == 0 {
+		return nil
+	}
+	links := make([]Link, len(p))
+	for i, item := range p {
+		links[i] = Link{Text: item.Key, URL: item.Value}
+	}
+	return links
+}
+
+// images 转换为图片列表
+func (p pairList) images() []Image {
+	if len(p) == 0 {
+		return nil
+	}
+	images := make([]Image, len(p))
+	for i, item := range p {
+		images[i] = Image{Alt: item.Key, URL: item.Value}
+	}
+	return images
+}
+
+// 纯文本响应末尾的摘要段落标题
+const (
+	imagesSectionTitle = "Images:"
+	linksSectionTitle  = "Links/Buttons:"
+)
+
+var (
+	summaryImageLine = regexp.MustCompile(`^- !\[(.*)\]\((\S+?)\)$`)
+	summaryLinkLine  = regexp.MustCompile(`^- \[(.*)\]\((\S+?)\)$`)
+)
+
+// extractSummaries 从正文末尾拆出 Images: 和 Links/Buttons: 摘要段落
+//
+// 非 JSON 响应中，X-With-Images-Summary/X-With-Links-Summary 的结果以 Markdown 列表
+// 追加在正文后面。返回去掉摘要后的正文和解析出的列表；没有摘要时原样返回正文。
+func extractSummaries(content string) (string, []Link, []Image) {
+	lines := strings.Split(content, "\n")
+
+	// 从末尾向前查找只包含空行、列表项和段落标题的区域
+	cut := len(lines)
+scan:
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		switch {
+		case line == "" || strings.HasPrefix(line, "- "):
+		case line == imagesSectionTitle || line == linksSectionTitle:
+			cut = i
+		default:
+			break scan
+		}
+	}
+	if cut == len(lines) {
+		return content, nil, nil
+	}
+
+	var links []Link
+	var images []Image
+	section := ""
+	for _, line := range lines[cut:] {
+		line = strings.TrimSpace(line)
+		switch line {
+		case imagesSectionTitle, linksSectionTitle:
+			section = line
+			continue
+		}
+		switch section {
+		case imagesSectionTitle:
+			if m := summaryImageLine.FindStringSubmatch(line); m != nil {
+				images = append(images, Image{Alt: m[1], URL: m[2]})
+			}
+		case linksSectionTitle:
+			if m := summaryLinkLine.FindStringSubmatch(line); m != nil {
+				links = append(links, Link{Text: m[1], URL: m[2]})
+			}
+		}
+	}
+
+	body := strings.TrimRight(strings.Join(lines[:cut], "\n"), " \t\r\n")
+	return body, links, images
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestPairList_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  pairList
+	}{
+		{"null", `null`, nil},
+		{"object keeps order", `{"z":"https://z.com","a":"https://a.com"}`, pairList{{"z", "https://z.com"}, {"a", "https://a.com"}}},
+		{"array of pairs", `[["Home","https://h.com"],["Home","https://h2.com"],["bad"]]`, pairList{{"Home", "https://h.com"}, {"Home", "https://h2.com"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got pairList
+			if err := json.Unmarshal([]byte(tt.input), &got); err != nil {
+				t.Fatalf("Unmarshal() failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var got pairList
+	if err := json.Unmarshal([]byte(`"x"`), &got); err == nil {
+		t.Error("expected error for string input")
+	}
+}
+
+func TestExtractSummaries(t *testing.T) {
+	content := "# Title\n\n- body item\n\nText.\n\nImages:\n- ![Image 1: logo](https://a.com/logo.png)\n\nLinks/Buttons:\n- [Home](https://a.com/)\n- [Docs](https://a.com/docs)\n"
+
+	body, links, images := extractSummaries(content)
+	if body != "# Title\n\n- body item\n\nText." {
+		t.Errorf("body = %q", body)
+	}
+	wantLinks := []Link{{"Home", "https://a.com/"}, {"Docs", "https://a.com/docs"}}
+	if !reflect.DeepEqual(links, wantLinks) {
+		t.Errorf("links = %v, want %v", links, wantLinks)
+	}
+	wantImages := []Image{{"Image 1: logo", "https://a.com/logo.png"}}
+	if !reflect.DeepEqual(images, wantImages) {
+		t.Errorf("images = %v, want %v", images, wantImages)
+	}
+
+	// 没有摘要段落时原样返回
+	plain := "# Title\n\n- item\n"
+	if body, links, images := extractSummaries(plain); body != plain || links != nil || images != nil {
+		t.Errorf("extractSummaries(plain) = %q, %v, %v", body, links, images)
+	}
+}
+
+func TestClient_Read_Summaries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-With-Links-Summary") != "all" || r.Header.Get("X-With-Images-Summary") != "true" {
+			t.Errorf("summary headers not set: %v", r.Header)
+		}
+		_, _ = w.Write([]byte("Body\n\nLinks/Buttons:\n- [A](https://a.com)\n"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, server.URL, "", 30)
+	resp, err := client.Read(&ReadRequest{URL: "https://example.com", WithLinksSummary: true, WithImagesSummary: true})
+	if err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	if resp.Content != "Body" {
+		t.Errorf("Content = %q", resp.Content)
+	}
+	if len(resp.Links) != 1 || resp.Links[0].URL != "https://a.com" || resp.Images != nil {
+		t.Errorf("Links = %v, Images = %v", resp.Links, resp.Images)
+	}
+}
+
+func TestClient_Read_LinksSummaryDuplicateText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-With-Links-Summary") != "all" {
+			t.Errorf("X-With-Links-Summary = %q, want all", r.Header.Get("X-With-Links-Summary"))
+		}
+		_, _ = w.Write([]byte(`{"code":200,"data":{"url":"https://example.com","content":"Body","links":[["Read more","https://example.com/1"],["Read more","https://example.com/2"],["Home","https://example.com/"]]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, server.URL, "", 30)
+	resp, err := client.Read(&ReadRequest{URL: "https://example.com", WithLinksSummary: true, JSONResponse: true})
+	if err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	want := []Link{
+		{Text: "Read more", URL: "https://example.com/1"},
+		{Text: "Read more", URL: "https://example.com/2"},
+		{Text: "Home", URL: "https://example.com/"},
+	}
+	if len(resp.Links) != len(want) {
+		t.Fatalf("Links = %v, want %v", resp.Links, want)
+	}
+	for i, link := range resp.Links {
+		if link != want[i] {
+			t.Errorf("Links[%d] = %v, want %v", i, link, want[i])
+		}
+	}
+}
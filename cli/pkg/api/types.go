@@ -12,25 +12,77 @@ package api
 
 // ReadRequest Read 请求
 type ReadRequest struct {
-	URL              string
-	Method           string // GET or POST
-	ResponseFormat   string // markdown, html, text, screenshot
-	Headers          map[string]string
-	Timeout          int // 超时时间（秒）
-	NoCache          bool
-	ProxyURL         string
-	TargetSelector   string
-	WaitForSelector  string
-	Cookie           string
-	WithGeneratedAlt bool
-	PostMethod       bool // 使用 POST 方法（用于 SPA）
+	URL               string
+	Method            string // GET or POST
+	ResponseFormat    string // markdown, html, text, screenshot
+	Headers           map[string]string
+	Timeout           int    // 服务端超时时间（秒），通过 X-Timeout 发送，与 HTTP 客户端超时无关
+	CacheTolerance    string // 服务端缓存容忍度（秒），为空时不发送
+	TokenBudget       int    // 最大 Token 数，0 表示不限制
+	NoCache           bool
+	ProxyURL          string
+	TargetSelector    string
+	WaitForSelector   string
+	RemoveSelectors   []string // 读取前移除的元素
+	RetainImages      string   // 图片保留方式：none、all、alt，为空时使用服务端默认值
+	WithIframe        bool     // 读取 iframe 中的内容
+	WithShadowDOM     bool     // 读取 Shadow DOM 中的内容
+	Cookie            string
+	Engine            string // 抓取引擎：browser、direct、cf-browser-rendering，为空时由服务端选择
+	Locale            string // 浏览器语言区域，如 en-US
+	UserAgent         string // 抓取目标页面时使用的 User-Agent
+	Referer           string // 抓取目标页面时使用的 Referer
+	WithGeneratedAlt  bool
+	WithLinksSummary  bool   // 返回页面中所有链接的列表
+	WithImagesSummary bool   // 返回页面中所有图片的列表
+	PostMethod        bool   // 使用 POST 方法（用于 SPA）
+	HTML              string // 直接提交的 HTML 内容，不再抓取 URL，URL 仅用于解析相对链接
+	PDF               []byte // 直接提交的 PDF 内容，同 HTML
+	JSONResponse      bool   // 请求 JSON 格式响应，返回完整的页面元数据
 }
 
+// Engines Read API 支持的抓取引擎
+var Engines = []string{"browser", "direct", "cf-browser-rendering"}
+
 // ReadResponse Read 响应
 type ReadResponse struct {
-	Content string
-	URL     string
-	Title   string
+	Content       string
+	URL           string // 请求的 URL
+	FinalURL      string // 跳转后的最终 URL（仅 JSON 模式）
+	Title         string
+	Description   string
+	PublishedTime string
+	Images        []Image // 按页面顺序排列
+	Links         []Link  // 按页面顺序排列
+	Warning       string
+	Usage         Usage
+}
+
+// readEnvelope Read API 的 JSON 响应结构
+type readEnvelope struct {
+	Code   int          `json:"code"`
+	Status int          `json:"status"`
+	Data   *readEnvData `json:"data"`
+	Meta   struct {
+		Usage Usage `json:"usage"`
+	} `json:"meta"`
+}
+
+// readEnvData Read API JSON 响应中的页面数据
+type readEnvData struct {
+	Title         string   `json:"title"`
+	Description   string   `json:"description"`
+	URL           string   `json:"url"`
+	Content       string   `json:"content"`
+	HTML          string   `json:"html"` // html 格式在 JSON 模式下的正文
+	Text          string   `json:"text"` // text 格式在 JSON 模式下的正文
+	PublishedTime string   `json:"publishedTime"`
+	Images        pairList `json:"images"`
+	Links         pairList `json:"links"`
+	Warning       string   `json:"warning"`
+	Usage         Usage    `json:"usage"`
+	ScreenshotURL string   `json:"screenshotUrl"`
+	PageshotURL   string   `json:"pageshotUrl"`
 }
 
 // SearchRequest Search 请求
@@ -39,19 +91,55 @@ type SearchRequest struct {
 	Sites          []string
 	ResponseFormat string
 	Headers        map[string]string
-	Timeout        int
-	Limit          int
+	Timeout        int    // 服务端超时时间（秒），通过 X-Timeout 发送
+	CacheTolerance string // 服务端缓存容忍度（秒），为空时不发送
+	TokenBudget    int    // 最大 Token 数，0 表示不限制
+	Limit          int    // 每页结果数，通过 num 参数发送，0 表示使用服务端默认值
+	Page           int    // 页码（从 1 开始），0 表示不发送
+	Country        string // 搜索国家/地区代码（gl），如 us
+	Language       string // 搜索界面语言（hl），如 zh-cn
+	NoCache        bool
+	NoContent      bool // 不抓取结果页面内容，只返回标题、URL 和描述
+}
+
+// Usage Token 用量
+type Usage struct {
+	Tokens int `json:"tokens"`
 }
 
 // SearchResult 单个搜索结果
 type SearchResult struct {
-	Title   string
-	URL     string
-	Content string
+	Title       string
+	URL         string
+	Description string
+	Content     string
+	Date        string
+	Usage       Usage
 }
 
 // SearchResponse Search 响应
 type SearchResponse struct {
 	Query   string
 	Results []SearchResult
+	Usage   Usage
+}
+
+// searchEnvelope Search API 的 JSON 响应结构
+type searchEnvelope struct {
+	Code   int               `json:"code"`
+	Status int               `json:"status"`
+	Data   []searchEnvResult `json:"data"`
+	Meta   struct {
+		Usage Usage `json:"usage"`
+	} `json:"meta"`
+}
+
+// searchEnvResult Search API JSON 响应中的单个结果
+type searchEnvResult struct {
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Description string `json:"description"`
+	Content     string `json:"content"`
+	Date        string `json:"date"`
+	Usage       Usage  `json:"usage"`
 }
@@ -0,0 +1,241 @@
+// Package cache 提供 API 响应的本地磁盘缓存。
+//
+// 每个缓存条目保存为缓存目录下的一个 JSON 文件，文件名为缓存键（请求的 SHA-256）。
+// 条目创建时间超过 TTL 即视为过期，读取时忽略，由 Prune 按指定的有效期清理。
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DirName 缓存目录名（位于配置目录下）
+const DirName = "cache"
+
+// entryExt 缓存条目文件扩展名
+const entryExt = ".json"
+
+// Entry 缓存条目
+type Entry struct {
+	Key     string    `json:"key"`
+	URL     string    `json:"url"`
+	Created time.Time `json:"created"`
+	Size    int64     `json:"size"`
+	Body    string    `json:"body,omitempty"`
+}
+
+// Expired 判断条目在 now 时是否已超过 ttl
+func (e Entry) Expired(ttl time.Duration, now time.Time) bool {
+	return now.Sub(e.Created) > ttl
+}
+
+// Stats 缓存统计
+type Stats struct {
+	Dir     string `json:"dir"`
+	Entries int    `json:"entries"`
+	Expired int    `json:"expired"`
+	Bytes   int64  `json:"bytes"`
+	TTL     int    `json:"ttl_seconds"`
+}
+
+// Cache 本地磁盘缓存，可被多个 goroutine 和进程并发使用
+type Cache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// New 创建缓存，dir 不存在时在首次写入时创建
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{dir: dir, ttl: ttl, now: time.Now}
+}
+
+// Dir 返回缓存目录
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// TTL 返回缓存有效期
+func (c *Cache) TTL() time.Duration {
+	return c.ttl
+}
+
+// Get 读取未过期的缓存内容
+func (c *Cache) Get(key string) ([]byte, bool) {
+	entry, err := c.read(c.path(key))
+	if err != nil || entry.Expired(c.ttl, c.now()) {
+		return nil, false
+	}
+	return []byte(entry.Body), true
+}
+
+// Put 写入缓存，url 仅用于展示
+func (c *Cache) Put(key, url string, body []byte) error {
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return fmt.Errorf("创建缓存目录失败: %w", err)
+	}
+
+	data, err := json.Marshal(Entry{
+		Key:     key,
+		URL:     url,
+		Created: c.now().UTC(),
+		Size:    int64(len(body)),
+		Body:    string(body),
+	})
+	if err != nil {
+		return fmt.Errorf("编码缓存条目失败: %w", err)
+	}
+
+	// 先写临时文件再重命名，避免并发读取到不完整的条目
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("写入缓存失败: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("写入缓存失败: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("写入缓存失败: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("写入缓存失败: %w", err)
+	}
+	return nil
+}
+
+// List 列出所有缓存条目（不含内容），按创建时间从新到旧排序
+func (c *Cache) List() ([]Entry, error) {
+	files, err := c.files()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(files))
+	for _, path := range files {
+		entry, err := c.read(path)
+		if err != nil {
+			continue
+		}
+		entry.Body = ""
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Created.After(entries[j].Created)
+	})
+	return entries, nil
+}
+
+// Stats 统计缓存条目数、过期条目数和总大小
+func (c *Cache) Stats() (Stats, error) {
+	stats := Stats{Dir: c.dir, TTL: int(c.ttl / time.Second)}
+	entries, err := c.List()
+	if err != nil {
+		return stats, err
+	}
+
+	now := c.now()
+	for _, entry := range entries {
+		stats.Entries++
+		stats.Bytes += entry.Size
+		if entry.Expired(c.ttl, now) {
+			stats.Expired++
+		}
+	}
+	return stats, nil
+}
+
+// Prune 删除创建时间超过 maxAge 和无法解析的条目，返回删除的条目数和释放的字节数
+//
+// maxAge 必须大于 0，否则所有条目都会被视为过期。
+func (c *Cache) Prune(maxAge time.Duration) (int, int64, error) {
+	if maxAge <= 0 {
+		return 0, 0, fmt.Errorf("清理缓存的有效期必须大于 0")
+	}
+	files, err := c.files()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	now := c.now()
+	removed := 0
+	var freed int64
+	for _, path := range files {
+		entry, err := c.read(path)
+		if err == nil && !entry.Expired(maxAge, now) {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return removed, freed, fmt.Errorf("删除缓存条目失败: %w", err)
+		}
+		removed++
+		freed += entry.Size
+	}
+	return removed, freed, nil
+}
+
+// Clear 删除所有缓存条目，返回删除的条目数和释放的字节数
+func (c *Cache) Clear() (int, int64, error) {
+	files, err := c.files()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	removed := 0
+	var freed int64
+	for _, path := range files {
+		if entry, err := c.read(path); err == nil {
+			freed += entry.Size
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return removed, freed, fmt.Errorf("删除缓存条目失败: %w", err)
+		}
+		removed++
+	}
+	return removed, freed, nil
+}
+
+// path 返回缓存键对应的文件路径
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+entryExt)
+}
+
+// files 列出缓存目录中的条目文件，目录不存在时返回空列表
+func (c *Cache) files() ([]string, error) {
+	dirEntries, err := os.ReadDir(c.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取缓存目录失败: %w", err)
+	}
+
+	var files []string
+	for _, d := range dirEntries {
+		if d.Type().IsRegular() && strings.HasSuffix(d.Name(), entryExt) {
+			files = append(files, filepath.Join(c.dir, d.Name()))
+		}
+	}
+	return files, nil
+}
+
+// read 读取缓存条目
+func (c *Cache) read(path string) (Entry, error) {
+	var entry Entry
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entry, err
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return entry, err
+	}
+	return entry, nil
+}
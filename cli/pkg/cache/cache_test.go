@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestCache(t *testing.T, ttl time.Duration) (*Cache, *time.Time) {
+	t.Helper()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := New(filepath.Join(t.TempDir(), DirName), ttl)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestCache_GetPut(t *testing.T) {
+	c, now := newTestCache(t, time.Minute)
+
+	if _, ok := c.Get("missing"); ok {
+		t.Error("Get() on empty cache should miss")
+	}
+
+	if err := c.Put("k1", "https://r.jina.ai/https://example.com", []byte("body")); err != nil {
+		t.Fatalf("Put() failed: %v", err)
+	}
+	body, ok := c.Get("k1")
+	if !ok || string(body) != "body" {
+		t.Errorf("Get() = %q, %v; want body, true", body, ok)
+	}
+
+	// 超过有效期后不再命中
+	*now = now.Add(2 * time.Minute)
+	if _, ok := c.Get("k1"); ok {
+		t.Error("Get() should miss after TTL")
+	}
+}
+
+func TestCache_ListStatsPrune(t *testing.T) {
+	c, now := newTestCache(t, time.Minute)
+
+	if err := c.Put("old", "https://a.com", []byte("12345")); err != nil {
+		t.Fatalf("Put() failed: %v", err)
+	}
+	*now = now.Add(90 * time.Second)
+	if err := c.Put("new", "https://b.com", []byte("123")); err != nil {
+		t.Fatalf("Put() failed: %v", err)
+	}
+	// 无法解析的条目由 Prune 清理
+	if err := os.WriteFile(filepath.Join(c.Dir(), "broken"+entryExt), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := c.List()
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Key != "new" || entries[1].Key != "old" {
+		t.Fatalf("List() = %+v", entries)
+	}
+	if entries[0].Body != "" {
+		t.Error("List() should not include bodies")
+	}
+
+	stats, err := c.Stats()
+	if err != nil {
+		t.Fatalf("Stats() failed: %v", err)
+	}
+	if stats.Entries != 2 || stats.Expired != 1 || stats.Bytes != 8 || stats.TTL != 60 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+
+	// 有效期为 0 时拒绝清理，否则会删除所有条目
+	if _, _, err := c.Prune(0); err == nil {
+		t.Error("Prune(0) expected error")
+	}
+	removed, freed, err := c.Prune(c.TTL())
+	if err != nil {
+		t.Fatalf("Prune() failed: %v", err)
+	}
+	if removed != 2 || freed != 5 {
+		t.Errorf("Prune() = %d, %d; want 2, 5", removed, freed)
+	}
+	if _, ok := c.Get("new"); !ok {
+		t.Error("Prune() removed a fresh entry")
+	}
+
+	removed, freed, err = c.Clear()
+	if err != nil {
+		t.Fatalf("Clear() failed: %v", err)
+	}
+	if removed != 1 || freed != 3 {
+		t.Errorf("Clear() = %d, %d; want 1, 3", removed, freed)
+	}
+}
+
+func TestCache_MissingDir(t *testing.T) {
+	c := New(filepath.Join(t.TempDir(), "absent"), time.Minute)
+
+	entries, err := c.List()
+	if err != nil || len(entries) != 0 {
+		t.Errorf("List() = %v, %v; want empty", entries, err)
+	}
+	if removed, _, err := c.Clear(); err != nil || removed != 0 {
+		t.Errorf("Clear() = %d, %v; want 0, nil", removed, err)
+	}
+}
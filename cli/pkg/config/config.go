@@ -10,19 +10,35 @@
 //   - timeout: 请求超时时间（秒）
 //   - with_generated_alt: 启用图片描述
 //   - proxy_url: 代理服务器 URL
-//   - cache_tolerance: 缓存容忍度（秒）
+//   - cache_tolerance: 缓存容忍度（秒），同时作为本地响应缓存的有效期
+//   - max_retries: 失败请求的最大重试次数
+//   - retry_delay: 首次重试前的等待时间（毫秒），之后指数增长
+//   - concurrency: 批量读取的并发数
+//   - server_timeout: 服务端等待页面加载的超时时间（秒），与请求超时分开
+//   - token_budget: 单次请求允许消耗的最大 Token 数
+//   - remove_selector: 读取时移除的元素（CSS 选择器，多个用逗号分隔）
+//   - retain_images: 图片保留方式 (none/all/alt)
+//   - with_iframe: 读取 iframe 中的内容
+//   - with_shadow_dom: 读取 Shadow DOM 中的内容
 //   - api_key: API 密钥
 //
+// 文件末尾的 [headers] 段落为每个请求默认附加的请求头，格式为 Name=value，
+// 通过 header.<Name> 配置项读写，例如 jina config set header.X-Locale en-US。
+//
 // 配置优先级: 命令行参数 > 环境变量 > 配置文件 > 默认值
 package config
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"slices"
+	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/geekjourneyx/jina-cli/cli/pkg/httpheader"
 )
 
 const (
@@ -34,8 +50,23 @@ const (
 	ConfigDir = ".jina-reader"
 	// ConfigFile 配置文件名
 	ConfigFile = "config.yaml"
+	// DefaultMaxRetries 默认最大重试次数
+	DefaultMaxRetries = 2
+	// DefaultRetryDelay 默认首次重试等待时间（毫秒）
+	DefaultRetryDelay = 500
+	// DefaultConcurrency 默认批量读取并发数
+	DefaultConcurrency = 1
 )
 
+// HeaderKeyPrefix 请求头配置项的前缀，如 header.X-Locale
+const HeaderKeyPrefix = "header."
+
+// headersSection 配置文件中请求头段落的标题
+const headersSection = "[headers]"
+
+// RetainImagesModes retain_images 的可选值
+var RetainImagesModes = []string{"none", "all", "alt"}
+
 var (
 	// configPath 配置文件完整路径
 	configPath string
@@ -72,7 +103,17 @@ type Config struct {
 	WithGeneratedAlt      bool
 	ProxyURL              string
 	CacheTolerance        string
+	MaxRetries            int
+	RetryDelay            int // 毫秒
+	Concurrency           int
+	ServerTimeout         int // 秒，0 表示使用服务端默认值
+	TokenBudget           int // 0 表示不限制
+	RemoveSelector        string
+	RetainImages          string
+	WithIframe            bool
+	WithShadowDOM         bool
 	APIKey                string
+	Headers               map[string]string // 默认附加到每个请求的请求头
 }
 
 // Load 从配置文件加载配置
@@ -84,6 +125,9 @@ func Load() (*Config, error) {
 		DefaultOutputFormat:   "json",
 		Timeout:               30,
 		WithGeneratedAlt:      false,
+		MaxRetries:            DefaultMaxRetries,
+		RetryDelay:            DefaultRetryDelay,
+		Concurrency:           DefaultConcurrency,
 	}
 
 	// 如果配置文件不存在，返回默认配置
@@ -100,15 +144,26 @@ func Load() (*Config, error) {
 	// 简单的 key=value 解析（为了保持轻量，不依赖 yaml 库）
 	// 格式: key=value
 	lines := splitLines(data)
+	section := ""
 	for _, line := range lines {
 		line = trimSpace(line)
 		if line == "" || line[0] == '#' {
 			continue
 		}
+		if line[0] == '[' {
+			section = line
+			continue
+		}
 		key, value, ok := parseKeyValue(line)
 		if !ok {
 			continue
 		}
+		if section == headersSection {
+			if httpheader.Validate(key, value) == nil {
+				setHeader(cfg, key, value)
+			}
+			continue
+		}
 		switch key {
 		case "api_base_url":
 			cfg.ReadAPIURL = value
@@ -128,6 +183,34 @@ func Load() (*Config, error) {
 			cfg.ProxyURL = value
 		case "cache_tolerance":
 			cfg.CacheTolerance = value
+		case "max_retries":
+			if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+				cfg.MaxRetries = n
+			}
+		case "retry_delay":
+			if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+				cfg.RetryDelay = n
+			}
+		case "concurrency":
+			if n, err := strconv.Atoi(value); err == nil && n > 0 {
+				cfg.Concurrency = n
+			}
+		case "server_timeout":
+			if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+				cfg.ServerTimeout = n
+			}
+		case "token_budget":
+			if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+				cfg.TokenBudget = n
+			}
+		case "remove_selector":
+			cfg.RemoveSelector = value
+		case "retain_images":
+			cfg.RetainImages = value
+		case "with_iframe":
+			cfg.WithIframe = strings.ToLower(value) == "true" || value == "1"
+		case "with_shadow_dom":
+			cfg.WithShadowDOM = strings.ToLower(value) == "true" || value == "1"
 		case "api_key":
 			cfg.APIKey = value
 		}
@@ -164,6 +247,43 @@ func applyEnvOverrides(cfg *Config) *Config {
 	if v := os.Getenv("JINA_CACHE_TOLERANCE"); v != "" {
 		cfg.CacheTolerance = v
 	}
+	if v := os.Getenv("JINA_MAX_RETRIES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.MaxRetries = n
+		}
+	}
+	if v := os.Getenv("JINA_RETRY_DELAY"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.RetryDelay = n
+		}
+	}
+	if v := os.Getenv("JINA_CONCURRENCY"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.Concurrency = n
+		}
+	}
+	if v := os.Getenv("JINA_SERVER_TIMEOUT"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.ServerTimeout = n
+		}
+	}
+	if v := os.Getenv("JINA_TOKEN_BUDGET"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.TokenBudget = n
+		}
+	}
+	if v := os.Getenv("JINA_REMOVE_SELECTOR"); v != "" {
+		cfg.RemoveSelector = v
+	}
+	if v := os.Getenv("JINA_RETAIN_IMAGES"); v != "" {
+		cfg.RetainImages = v
+	}
+	if v := os.Getenv("JINA_WITH_IFRAME"); v != "" {
+		cfg.WithIframe = strings.ToLower(v) == "true" || v == "1"
+	}
+	if v := os.Getenv("JINA_WITH_SHADOW_DOM"); v != "" {
+		cfg.WithShadowDOM = strings.ToLower(v) == "true" || v == "1"
+	}
 	if v := os.Getenv("JINA_API_KEY"); v != "" {
 		cfg.APIKey = v
 	}
@@ -193,6 +313,16 @@ func Save(cfg *Config) error {
 	content += "#   with_generated_alt       - 启用图片描述（默认：false）\n"
 	content += "#   proxy_url                - 代理服务器 URL\n"
 	content += "#   cache_tolerance          - 缓存容忍度，单位：秒\n"
+	content += "#   max_retries              - 失败请求的最大重试次数（默认：2）\n"
+	content += "#   retry_delay              - 首次重试等待时间，单位：毫秒（默认：500）\n"
+	content += "#   concurrency              - 批量读取的并发数（默认：1）\n"
+	content += "#   server_timeout           - 服务端页面加载超时，单位：秒\n"
+	content += "#   token_budget             - 单次请求的最大 Token 数\n"
+	content += "#   remove_selector          - 读取时移除的元素（CSS 选择器，多个用逗号分隔）\n"
+	content += "#   retain_images            - 图片保留方式\n"
+	content += "#     可选值: none, all, alt\n"
+	content += "#   with_iframe              - 读取 iframe 中的内容（默认：false）\n"
+	content += "#   with_shadow_dom          - 读取 Shadow DOM 中的内容（默认：false）\n"
 	content += "#   api_key                  - API 密钥（如果需要）\n"
 	content += "#\n\n"
 
@@ -220,9 +350,42 @@ func Save(cfg *Config) error {
 	if cfg.CacheTolerance != "" {
 		content += fmt.Sprintf("cache_tolerance=%s\n", cfg.CacheTolerance)
 	}
+	if cfg.MaxRetries != DefaultMaxRetries {
+		content += fmt.Sprintf("max_retries=%d\n", cfg.MaxRetries)
+	}
+	if cfg.RetryDelay != DefaultRetryDelay {
+		content += fmt.Sprintf("retry_delay=%d\n", cfg.RetryDelay)
+	}
+	if cfg.Concurrency > DefaultConcurrency {
+		content += fmt.Sprintf("concurrency=%d\n", cfg.Concurrency)
+	}
+	if cfg.ServerTimeout > 0 {
+		content += fmt.Sprintf("server_timeout=%d\n", cfg.ServerTimeout)
+	}
+	if cfg.TokenBudget > 0 {
+		content += fmt.Sprintf("token_budget=%d\n", cfg.TokenBudget)
+	}
+	if cfg.RemoveSelector != "" {
+		content += fmt.Sprintf("remove_selector=%s\n", cfg.RemoveSelector)
+	}
+	if cfg.RetainImages != "" {
+		content += fmt.Sprintf("retain_images=%s\n", cfg.RetainImages)
+	}
+	if cfg.WithIframe {
+		content += fmt.Sprintf("with_iframe=%t\n", cfg.WithIframe)
+	}
+	if cfg.WithShadowDOM {
+		content += fmt.Sprintf("with_shadow_dom=%t\n", cfg.WithShadowDOM)
+	}
 	if cfg.APIKey != "" {
 		content += fmt.Sprintf("api_key=%s\n", cfg.APIKey)
 	}
+	if len(cfg.Headers) > 0 {
+		content += "\n" + headersSection + "\n"
+		for _, name := range headerNames(cfg) {
+			content += fmt.Sprintf("%s=%s\n", name, cfg.Headers[name])
+		}
+	}
 
 	// 写入文件
 	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
@@ -239,6 +402,19 @@ func Set(key, value string) error {
 		return err
 	}
 
+	// 请求头名称区分连字符，单独处理；值为空时删除
+	if name, ok := strings.CutPrefix(key, HeaderKeyPrefix); ok {
+		if err := httpheader.Validate(name, value); err != nil {
+			return err
+		}
+		if value == "" {
+			delete(cfg.Headers, http.CanonicalHeaderKey(name))
+		} else {
+			setHeader(cfg, name, value)
+		}
+		return Save(cfg)
+	}
+
 	// 支持下划线和连字符两种格式
 	normalizedKey := strings.ReplaceAll(key, "-", "_")
 
@@ -262,7 +438,53 @@ func Set(key, value string) error {
 	case "proxy_url":
 		cfg.ProxyURL = value
 	case "cache_tolerance":
+		if value != "" {
+			if n, err := strconv.Atoi(value); err != nil || n < 0 {
+				return fmt.Errorf("无效的缓存容忍度: %s", value)
+			}
+		}
 		cfg.CacheTolerance = value
+	case "max_retries":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("无效的重试次数: %s", value)
+		}
+		cfg.MaxRetries = n
+	case "retry_delay":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("无效的重试等待时间: %s", value)
+		}
+		cfg.RetryDelay = n
+	case "concurrency":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return fmt.Errorf("无效的并发数: %s", value)
+		}
+		cfg.Concurrency = n
+	case "server_timeout":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("无效的服务端超时值: %s", value)
+		}
+		cfg.ServerTimeout = n
+	case "token_budget":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("无效的 Token 预算: %s", value)
+		}
+		cfg.TokenBudget = n
+	case "remove_selector":
+		cfg.RemoveSelector = value
+	case "retain_images":
+		if value != "" && !slices.Contains(RetainImagesModes, value) {
+			return fmt.Errorf("无效的图片保留方式: %s（可选 none、all、alt）", value)
+		}
+		cfg.RetainImages = value
+	case "with_iframe":
+		cfg.WithIframe = strings.ToLower(value) == "true" || value == "1"
+	case "with_shadow_dom":
+		cfg.WithShadowDOM = strings.ToLower(value) == "true" || value == "1"
 	case "api_key":
 		cfg.APIKey = value
 	default:
@@ -282,6 +504,11 @@ func Get(key string) (string, error) {
 	// 支持下划线和连字符两种格式
 	normalizedKey := strings.ReplaceAll(key, "-", "_")
 
+	if name, ok := strings.CutPrefix(key, HeaderKeyPrefix); ok {
+		name = http.CanonicalHeaderKey(name)
+		return httpheader.Mask(name, cfg.Headers[name]), nil
+	}
+
 	switch normalizedKey {
 	case "api_base_url":
 		return cfg.ReadAPIURL, nil
@@ -299,6 +526,24 @@ func Get(key string) (string, error) {
 		return cfg.ProxyURL, nil
 	case "cache_tolerance":
 		return cfg.CacheTolerance, nil
+	case "max_retries":
+		return strconv.Itoa(cfg.MaxRetries), nil
+	case "retry_delay":
+		return strconv.Itoa(cfg.RetryDelay), nil
+	case "concurrency":
+		return strconv.Itoa(cfg.Concurrency), nil
+	case "server_timeout":
+		return strconv.Itoa(cfg.ServerTimeout), nil
+	case "token_budget":
+		return strconv.Itoa(cfg.TokenBudget), nil
+	case "remove_selector":
+		return cfg.RemoveSelector, nil
+	case "retain_images":
+		return cfg.RetainImages, nil
+	case "with_iframe":
+		return strconv.FormatBool(cfg.WithIframe), nil
+	case "with_shadow_dom":
+		return strconv.FormatBool(cfg.WithShadowDOM), nil
 	case "api_key":
 		if cfg.APIKey == "" {
 			return "", nil
@@ -325,15 +570,45 @@ func List() (map[string]string, error) {
 	result["with_generated_alt"] = strconv.FormatBool(cfg.WithGeneratedAlt)
 	result["proxy_url"] = cfg.ProxyURL
 	result["cache_tolerance"] = cfg.CacheTolerance
+	result["max_retries"] = strconv.Itoa(cfg.MaxRetries)
+	result["retry_delay"] = strconv.Itoa(cfg.RetryDelay)
+	result["concurrency"] = strconv.Itoa(cfg.Concurrency)
+	result["server_timeout"] = strconv.Itoa(cfg.ServerTimeout)
+	result["token_budget"] = strconv.Itoa(cfg.TokenBudget)
+	result["remove_selector"] = cfg.RemoveSelector
+	result["retain_images"] = cfg.RetainImages
+	result["with_iframe"] = strconv.FormatBool(cfg.WithIframe)
+	result["with_shadow_dom"] = strconv.FormatBool(cfg.WithShadowDOM)
 	if cfg.APIKey != "" {
 		result["api_key"] = maskSensitive(cfg.APIKey)
 	} else {
 		result["api_key"] = ""
 	}
+	for name, value := range cfg.Headers {
+		result[HeaderKeyPrefix+name] = httpheader.Mask(name, value)
+	}
 
 	return result, nil
 }
 
+// setHeader 设置默认请求头，名称按 HTTP 规范化
+func setHeader(cfg *Config, name, value string) {
+	if cfg.Headers == nil {
+		cfg.Headers = make(map[string]string)
+	}
+	cfg.Headers[http.CanonicalHeaderKey(name)] = value
+}
+
+// headerNames 返回排序后的默认请求头名称
+func headerNames(cfg *Config) []string {
+	names := make([]string, 0, len(cfg.Headers))
+	for name := range cfg.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // maskSensitive 掩码敏感信息
 func maskSensitive(s string) string {
 	if len(s) <= 8 {
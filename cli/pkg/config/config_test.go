@@ -46,6 +46,12 @@ func TestLoad_DefaultConfig(t *testing.T) {
 	if cfg.WithGeneratedAlt {
 		t.Errorf("Expected WithGeneratedAlt false, got %t", cfg.WithGeneratedAlt)
 	}
+	if cfg.MaxRetries != DefaultMaxRetries {
+		t.Errorf("Expected MaxRetries %d, got %d", DefaultMaxRetries, cfg.MaxRetries)
+	}
+	if cfg.RetryDelay != DefaultRetryDelay {
+		t.Errorf("Expected RetryDelay %d, got %d", DefaultRetryDelay, cfg.RetryDelay)
+	}
 }
 
 func TestLoad_WithConfigFile(t *testing.T) {
@@ -234,6 +240,135 @@ func TestSet(t *testing.T) {
 				return nil
 			},
 		},
+		{
+			name:  "set max-retries",
+			key:   "max-retries",
+			value: "5",
+			verify: func(t *testing.T, cfg *Config) error {
+				if cfg.MaxRetries != 5 {
+					return fmt.Errorf("expected MaxRetries 5, got %d", cfg.MaxRetries)
+				}
+				return nil
+			},
+		},
+		{
+			name:  "set retry_delay",
+			key:   "retry_delay",
+			value: "1000",
+			verify: func(t *testing.T, cfg *Config) error {
+				if cfg.RetryDelay != 1000 {
+					return fmt.Errorf("expected RetryDelay 1000, got %d", cfg.RetryDelay)
+				}
+				return nil
+			},
+		},
+		{
+			name:  "set concurrency",
+			key:   "concurrency",
+			value: "8",
+			verify: func(t *testing.T, cfg *Config) error {
+				if cfg.Concurrency != 8 {
+					return fmt.Errorf("expected Concurrency 8, got %d", cfg.Concurrency)
+				}
+				return nil
+			},
+		},
+		{
+			name:  "set server_timeout",
+			key:   "server-timeout",
+			value: "20",
+			verify: func(t *testing.T, cfg *Config) error {
+				if cfg.ServerTimeout != 20 {
+					return fmt.Errorf("expected ServerTimeout 20, got %d", cfg.ServerTimeout)
+				}
+				return nil
+			},
+		},
+		{
+			name:  "set token_budget",
+			key:   "token_budget",
+			value: "50000",
+			verify: func(t *testing.T, cfg *Config) error {
+				if cfg.TokenBudget != 50000 {
+					return fmt.Errorf("expected TokenBudget 50000, got %d", cfg.TokenBudget)
+				}
+				return nil
+			},
+		},
+		{
+			name:  "set remove_selector",
+			key:   "remove_selector",
+			value: "nav, footer, .cookie-banner",
+			verify: func(t *testing.T, cfg *Config) error {
+				if cfg.RemoveSelector != "nav, footer, .cookie-banner" {
+					return fmt.Errorf("expected RemoveSelector 'nav, footer, .cookie-banner', got %s", cfg.RemoveSelector)
+				}
+				return nil
+			},
+		},
+		{
+			name:  "set retain-images",
+			key:   "retain-images",
+			value: "none",
+			verify: func(t *testing.T, cfg *Config) error {
+				if cfg.RetainImages != "none" {
+					return fmt.Errorf("expected RetainImages none, got %s", cfg.RetainImages)
+				}
+				return nil
+			},
+		},
+		{
+			name:      "invalid retain_images",
+			key:       "retain_images",
+			value:     "some",
+			expectErr: true,
+		},
+		{
+			name:  "set with_shadow_dom",
+			key:   "with_shadow_dom",
+			value: "true",
+			verify: func(t *testing.T, cfg *Config) error {
+				if !cfg.WithShadowDOM {
+					return fmt.Errorf("expected WithShadowDOM true")
+				}
+				return nil
+			},
+		},
+		{
+			name:      "invalid token_budget",
+			key:       "token_budget",
+			value:     "-5",
+			expectErr: true,
+		},
+		{
+			name:      "invalid concurrency",
+			key:       "concurrency",
+			value:     "0",
+			expectErr: true,
+		},
+		{
+			name:      "invalid max_retries",
+			key:       "max_retries",
+			value:     "-1",
+			expectErr: true,
+		},
+		{
+			name:  "set cache_tolerance",
+			key:   "cache_tolerance",
+			value: "3600",
+			verify: func(t *testing.T, cfg *Config) error {
+				if cfg.CacheTolerance != "3600" {
+					return fmt.Errorf("expected CacheTolerance 3600, got %s", cfg.CacheTolerance)
+				}
+				return nil
+			},
+		},
+		{
+			name:      "invalid cache_tolerance",
+			key:       "cache_tolerance",
+			value:     "1h",
+			expectErr: true,
+		},
 		{
 			name:      "invalid timeout",
 			key:       "timeout",
@@ -383,6 +518,15 @@ func TestList(t *testing.T) {
 		"with_generated_alt",
 		"proxy_url",
 		"cache_tolerance",
+		"max_retries",
+		"retry_delay",
+		"concurrency",
+		"server_timeout",
+		"token_budget",
+		"remove_selector",
+		"retain_images",
+		"with_iframe",
+		"with_shadow_dom",
 		"api_key",
 	}
 
@@ -403,6 +547,70 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestHeaders(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalConfigDir := configDir
+	originalConfigPath := configPath
+	defer func() {
+		configDir = originalConfigDir
+		configPath = originalConfigPath
+	}()
+
+	configDir = tmpDir
+	configPath = filepath.Join(tmpDir, ConfigFile)
+
+	if err := Set("header.x-locale", "en-US"); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+	if err := Set("header.X-Api-Token", "secret-token-1234"); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+	if err := Set("header.Bad Name", "v"); err == nil {
+		t.Error("Set() should reject invalid header name")
+	}
+
+	// 请求头保存在 [headers] 段落中
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	if !strings.Contains(string(data), "[headers]\nX-Api-Token=secret-token-1234\nX-Locale=en-US\n") {
+		t.Errorf("unexpected headers section:\n%s", data)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.Headers["X-Locale"] != "en-US" || cfg.Headers["X-Api-Token"] != "secret-token-1234" {
+		t.Errorf("unexpected headers: %v", cfg.Headers)
+	}
+	// [headers] 段落中的键不影响普通配置项
+	if cfg.Timeout != 30 {
+		t.Errorf("Expected Timeout 30, got %d", cfg.Timeout)
+	}
+
+	if got, _ := Get("header.X-Api-Token"); got != "secr***1234" {
+		t.Errorf("Get() should mask sensitive header, got %s", got)
+	}
+	list, err := List()
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	if list["header.X-Locale"] != "en-US" {
+		t.Errorf("List() header.X-Locale = %q", list["header.X-Locale"])
+	}
+
+	// 空值删除请求头
+	if err := Set("header.X-Locale", ""); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+	cfg, _ = Load()
+	if _, ok := cfg.Headers["X-Locale"]; ok {
+		t.Error("header.X-Locale should be removed")
+	}
+}
+
 func TestMaskSensitive(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -0,0 +1,293 @@
+// Package crawl 从起始页面出发按广度优先抓取同一站点的页面。
+//
+// 抓取范围限定在起始页面的主机和路径前缀内，URL 按 api.NormalizeURL 去重。
+// 抓取前检查目标站点的 robots.txt，同一主机的两次请求之间至少间隔 Options.Delay
+// （robots.txt 的 Crawl-delay 更长时以其为准）。页面的读取和输出由调用方的 Visitor 完成。
+package crawl
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/geekjourneyx/jina-cli/cli/pkg/api"
+)
+
+// DefaultUserAgent 匹配 robots.txt 规则时使用的 User-Agent
+const DefaultUserAgent = "jina-cli"
+
+// Page 待抓取的页面
+type Page struct {
+	URL   string
+	Depth int // 起始页面为 0
+}
+
+// Visitor 读取并输出一个页面，返回页面中的链接；index 为页面的抓取顺序
+//
+// Visitor 会被并发调用。
+type Visitor func(ctx context.Context, index int, page Page) []string
+
+// Fetcher 获取 robots.txt 内容
+type Fetcher func(ctx context.Context, url string) ([]byte, error)
+
+// Options 抓取选项
+type Options struct {
+	Scope       Scope
+	MaxDepth    int           // 跟随链接的最大深度，0 表示只抓取起始页面
+	MaxPages    int           // 最多抓取的页面数，0 表示不限制
+	Concurrency int           // 并发抓取的页面数，小于 1 时为 1
+	Delay       time.Duration // 同一主机两次请求的最小间隔
+
+	// Robots 获取 robots.txt，为 nil 时不检查；获取失败时视为不限制
+	Robots    Fetcher
+	UserAgent string // 匹配 robots.txt 规则的 User-Agent，为空时使用 DefaultUserAgent
+}
+
+// Stats 抓取统计
+type Stats struct {
+	Visited    int // 已分派的页面数，被中断时其中部分页面可能未读取
+	Disallowed int // 被 robots.txt 禁止而跳过的链接数
+	OutOfScope int // 超出抓取范围而跳过的链接数
+}
+
+// Scope 抓取范围：同一主机（忽略大小写和 www. 前缀）且路径以 PathPrefix 开头
+type Scope struct {
+	Host       string
+	PathPrefix string
+}
+
+// NewScope 根据起始 URL 创建抓取范围，prefix 为空时使用起始页面所在的目录
+//
+// 例如 https://go.dev/doc/effective_go 的默认范围为 go.dev 下的 /doc/。
+func NewScope(start, prefix string) (Scope, error) {
+	u, err := url.Parse(start)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Scope{}, fmt.Errorf("无效的起始 URL: %s", start)
+	}
+	if prefix == "" {
+		prefix = "/"
+		if dir := path.Dir(u.Path); strings.HasSuffix(u.Path, "/") {
+			prefix = u.Path
+		} else if dir != "." && dir != "/" {
+			prefix = dir + "/"
+		}
+	}
+	if !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+	return Scope{Host: scopeHost(u), PathPrefix: prefix}, nil
+}
+
+// Contains 判断 URL 是否在抓取范围内
+func (s Scope) Contains(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	p := u.Path
+	if p == "" {
+		p = "/"
+	}
+	// 前缀 /docs/ 同时包含 /docs 本身
+	return scopeHost(u) == s.Host &&
+		(strings.HasPrefix(p, s.PathPrefix) || p+"/" == s.PathPrefix)
+}
+
+func scopeHost(u *url.URL) string {
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	return strings.TrimSuffix(strings.TrimSuffix(host, ":80"), ":443")
+}
+
+// Crawl 从 start 开始逐层抓取，返回抓取统计
+//
+// 同一深度的页面并发抓取，全部完成后再处理下一层，抓取顺序与页面中的链接顺序一致。
+// 起始 URL 无效或被 robots.txt 禁止时返回错误；ctx 取消后不再抓取新页面。
+func Crawl(ctx context.Context, start string, opts Options, visit Visitor) (Stats, error) {
+	c := &crawler{
+		opts:   opts,
+		seen:   make(map[string]bool),
+		robots: make(map[string]*Robots),
+		next:   make(map[string]time.Time),
+	}
+	if c.opts.UserAgent == "" {
+		c.opts.UserAgent = DefaultUserAgent
+	}
+	if c.opts.Concurrency < 1 {
+		c.opts.Concurrency = 1
+	}
+
+	u, err := url.Parse(start)
+	if err != nil || u.Host == "" {
+		return c.stats, fmt.Errorf("无效的起始 URL: %s", start)
+	}
+	if !c.allowed(ctx, u) {
+		return c.stats, fmt.Errorf("robots.txt 禁止抓取起始页面: %s", start)
+	}
+	c.seen[api.NormalizeURL(start)] = true
+
+	level := []Page{{URL: start, Depth: 0}}
+	for len(level) > 0 && ctx.Err() == nil {
+		links := c.visitLevel(ctx, level, visit)
+		depth := level[0].Depth + 1
+		level = nil
+		if depth > c.opts.MaxDepth {
+			break
+		}
+		for _, link := range links {
+			if c.full(len(level)) {
+				break
+			}
+			if c.enqueue(ctx, link) {
+				level = append(level, Page{URL: link, Depth: depth})
+			}
+		}
+	}
+	return c.stats, nil
+}
+
+type crawler struct {
+	opts  Options
+	stats Stats
+	seen  map[string]bool // 已加入队列的 URL（NormalizeURL）
+
+	mu     sync.Mutex
+	robots map[string]*Robots   // 按 scheme://host 缓存的 robots.txt
+	next   map[string]time.Time // 每个主机下一次允许请求的时间
+}
+
+// full 判断加上下一层已排队的 queued 个页面后是否达到 MaxPages
+func (c *crawler) full(queued int) bool {
+	return c.opts.MaxPages > 0 && c.stats.Visited+queued >= c.opts.MaxPages
+}
+
+// visitLevel 并发抓取同一深度的页面，按页面顺序返回其中的链接（已解析为绝对 URL）
+func (c *crawler) visitLevel(ctx context.Context, level []Page, visit Visitor) []string {
+	base := c.stats.Visited
+	found := make([][]string, len(level))
+
+	sem := make(chan struct{}, c.opts.Concurrency)
+	var wg sync.WaitGroup
+	for i, page := range level {
+		if ctx.Err() != nil {
+			break
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		c.stats.Visited++
+		go func(i int, page Page) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			u, _ := url.Parse(page.URL)
+			if err := c.wait(ctx, u); err != nil {
+				return
+			}
+			for _, link := range visit(ctx, base+i, page) {
+				if abs := resolve(u, link); abs != "" {
+					found[i] = append(found[i], abs)
+				}
+			}
+		}(i, page)
+	}
+	wg.Wait()
+
+	var links []string
+	for _, l := range found {
+		links = append(links, l...)
+	}
+	return links
+}
+
+// enqueue 判断链接是否需要抓取并记录为已见
+func (c *crawler) enqueue(ctx context.Context, link string) bool {
+	key := api.NormalizeURL(link)
+	if c.seen[key] {
+		return false
+	}
+	c.seen[key] = true
+
+	if !c.opts.Scope.Contains(link) {
+		c.stats.OutOfScope++
+		return false
+	}
+	u, _ := url.Parse(link)
+	if !c.allowed(ctx, u) {
+		c.stats.Disallowed++
+		return false
+	}
+	return true
+}
+
+// resolve 将链接解析为不带片段的绝对 http(s) URL，无法解析时返回空字符串
+func resolve(base *url.URL, link string) string {
+	ref, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return ""
+	}
+	u := base.ResolveReference(ref)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
+
+// hostRobots 返回主机的 robots.txt 规则，首次调用时获取
+func (c *crawler) hostRobots(ctx context.Context, u *url.URL) *Robots {
+	if c.opts.Robots == nil {
+		return nil
+	}
+	origin := u.Scheme + "://" + u.Host
+
+	c.mu.Lock()
+	r, ok := c.robots[origin]
+	c.mu.Unlock()
+	if ok {
+		return r
+	}
+
+	if data, err := c.opts.Robots(ctx, origin+"/robots.txt"); err == nil {
+		r = ParseRobots(data, c.opts.UserAgent)
+	}
+	c.mu.Lock()
+	c.robots[origin] = r
+	c.mu.Unlock()
+	return r
+}
+
+// allowed 判断 robots.txt 是否允许抓取 URL
+func (c *crawler) allowed(ctx context.Context, u *url.URL) bool {
+	return c.hostRobots(ctx, u).Allowed(u.RequestURI())
+}
+
+// wait 等待到该主机允许下一次请求的时间，ctx 取消时返回错误
+func (c *crawler) wait(ctx context.Context, u *url.URL) error {
+	delay := c.opts.Delay
+	if r := c.hostRobots(ctx, u); r != nil && r.CrawlDelay > delay {
+		delay = r.CrawlDelay
+	}
+
+	c.mu.Lock()
+	now := time.Now()
+	at := c.next[u.Host]
+	if at.Before(now) {
+		at = now
+	}
+	c.next[u.Host] = at.Add(delay)
+	c.mu.Unlock()
+
+	if d := time.Until(at); d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,203 @@
+package crawl
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseRobots(t *testing.T) {
+	data := []byte(`# example
+User-agent: *
+Disallow: /private/
+Allow: /private/public
+Disallow: /*.pdf$
+Crawl-delay: 2
+
+User-agent: BadBot
+User-agent: jina-cli
+Disallow: /tmp
+Crawl-delay: 0.5
+`)
+
+	r := ParseRobots(data, "Mozilla/5.0")
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/", true},
+		{"/private/x", false},
+		{"/private/public/page", true},
+		{"/files/report.pdf", false},
+		{"/files/report.pdf?download=1", true},
+		{"/tmp", true},
+	}
+	for _, tt := range tests {
+		if got := r.Allowed(tt.path); got != tt.want {
+			t.Errorf("Allowed(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+	if r.CrawlDelay != 2*time.Second {
+		t.Errorf("CrawlDelay = %v, want 2s", r.CrawlDelay)
+	}
+
+	// 专属组优先于 *
+	r = ParseRobots(data, DefaultUserAgent)
+	if r.Allowed("/tmp/x") || !r.Allowed("/private/x") {
+		t.Error("jina-cli group not selected")
+	}
+	if r.CrawlDelay != 500*time.Millisecond {
+		t.Errorf("CrawlDelay = %v, want 500ms", r.CrawlDelay)
+	}
+
+	// 没有适用的组时不限制
+	r = ParseRobots([]byte("User-agent: OtherBot\nDisallow: /\n"), DefaultUserAgent)
+	if !r.Allowed("/anything") {
+		t.Error("unrelated group should not apply")
+	}
+	if !ParseRobots([]byte("User-agent: *\nDisallow:\n"), DefaultUserAgent).Allowed("/x") {
+		t.Error("empty Disallow should allow everything")
+	}
+}
+
+func TestScope(t *testing.T) {
+	tests := []struct {
+		start, prefix string
+		url           string
+		want          bool
+	}{
+		{"https://docs.example.com", "", "https://docs.example.com/guide", true},
+		{"https://docs.example.com", "", "http://www.docs.example.com/", true},
+		{"https://docs.example.com", "", "https://example.com/guide", false},
+		{"https://go.dev/doc/effective_go", "", "https://go.dev/doc/faq", true},
+		{"https://go.dev/doc/effective_go", "", "https://go.dev/doc", true},
+		{"https://go.dev/doc/effective_go", "", "https://go.dev/blog/", false},
+		{"https://go.dev/doc/", "/doc/tutorial/", "https://go.dev/doc/faq", false},
+		{"https://go.dev/", "", "mailto:a@go.dev", false},
+	}
+	for _, tt := range tests {
+		s, err := NewScope(tt.start, tt.prefix)
+		if err != nil {
+			t.Fatalf("NewScope(%q) error = %v", tt.start, err)
+		}
+		if got := s.Contains(tt.url); got != tt.want {
+			t.Errorf("NewScope(%q, %q).Contains(%q) = %v, want %v", tt.start, tt.prefix, tt.url, got, tt.want)
+		}
+	}
+	if _, err := NewScope("example.com", ""); err == nil {
+		t.Error("NewScope without scheme expected error")
+	}
+}
+
+// site 测试站点：页面 URL 到其中链接的映射
+var site = map[string][]string{
+	"https://ex.com/docs/":  {"/docs/a", "b", "https://ex.com/blog/", "/docs/private/x", "#top"},
+	"https://ex.com/docs/a": {"/docs/", "/docs/b#section", "/docs/c", "https://other.com/docs/"},
+	"https://ex.com/docs/b": {"/docs/d", "/docs/a?utm_source=x"},
+	"https://ex.com/docs/c": {"/docs/e"},
+}
+
+func crawlSite(t *testing.T, opts Options) ([]Page, Stats, error) {
+	t.Helper()
+	var mu sync.Mutex
+	var visited []Page
+	scope, _ := NewScope("https://ex.com/docs/", "")
+	opts.Scope = scope
+	opts.Robots = func(ctx context.Context, url string) ([]byte, error) {
+		if url != "https://ex.com/robots.txt" {
+			return nil, fmt.Errorf("unexpected robots.txt fetch: %s", url)
+		}
+		return []byte("User-agent: *\nDisallow: /docs/private/\n"), nil
+	}
+	stats, err := Crawl(context.Background(), "https://ex.com/docs/", opts, func(ctx context.Context, index int, page Page) []string {
+		mu.Lock()
+		visited = append(visited, page)
+		mu.Unlock()
+		return site[page.URL]
+	})
+	sort.Slice(visited, func(i, j int) bool { return visited[i].URL < visited[j].URL })
+	return visited, stats, err
+}
+
+func pageURLs(pages []Page) string {
+	s := make([]string, len(pages))
+	for i, p := range pages {
+		s[i] = fmt.Sprintf("%s@%d", strings.TrimPrefix(p.URL, "https://ex.com"), p.Depth)
+	}
+	return strings.Join(s, ",")
+}
+
+func TestCrawl(t *testing.T) {
+	pages, stats, err := crawlSite(t, Options{MaxDepth: 2, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("Crawl() error = %v", err)
+	}
+	want := "/docs/@0,/docs/a@1,/docs/b@1,/docs/c@2,/docs/d@2"
+	if got := pageURLs(pages); got != want {
+		t.Errorf("pages = %s, want %s", got, want)
+	}
+	if stats.Visited != 5 || stats.Disallowed != 1 || stats.OutOfScope != 2 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestCrawlLimits(t *testing.T) {
+	pages, _, _ := crawlSite(t, Options{MaxDepth: 0})
+	if got := pageURLs(pages); got != "/docs/@0" {
+		t.Errorf("depth 0 pages = %s", got)
+	}
+
+	pages, stats, _ := crawlSite(t, Options{MaxDepth: 5, MaxPages: 3})
+	if got := pageURLs(pages); got != "/docs/@0,/docs/a@1,/docs/b@1" {
+		t.Errorf("max pages = %s", got)
+	}
+	if stats.Visited != 3 {
+		t.Errorf("Visited = %d, want 3", stats.Visited)
+	}
+}
+
+func TestCrawlStartDisallowed(t *testing.T) {
+	robots := func(ctx context.Context, url string) ([]byte, error) {
+		return []byte("User-agent: *\nDisallow: /\n"), nil
+	}
+	_, err := Crawl(context.Background(), "https://ex.com/", Options{Robots: robots}, func(ctx context.Context, index int, page Page) []string {
+		t.Error("visitor should not be called")
+		return nil
+	})
+	if err == nil {
+		t.Error("Crawl() expected error")
+	}
+}
+
+func TestCrawlDelay(t *testing.T) {
+	var mu sync.Mutex
+	var times []time.Time
+	scope, _ := NewScope("https://ex.com/docs/", "")
+	start := time.Now()
+	_, err := Crawl(context.Background(), "https://ex.com/docs/", Options{
+		Scope:       scope,
+		MaxDepth:    1,
+		Concurrency: 4,
+		Delay:       30 * time.Millisecond,
+	}, func(ctx context.Context, index int, page Page) []string {
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+		return site[page.URL]
+	})
+	if err != nil {
+		t.Fatalf("Crawl() error = %v", err)
+	}
+	// 起始页面和第一层的 3 个页面共 4 个请求，同一主机至少间隔 3 个 Delay
+	if len(times) != 4 {
+		t.Fatalf("visited %d pages, want 4", len(times))
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	if elapsed := times[len(times)-1].Sub(start); elapsed < 90*time.Millisecond {
+		t.Errorf("elapsed = %v, want >= 90ms", elapsed)
+	}
+}
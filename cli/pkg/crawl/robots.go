@@ -0,0 +1,138 @@
+package crawl
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Robots 解析后的 robots.txt 中适用于某个 User-Agent 的规则，nil 表示不限制
+type Robots struct {
+	rules      []robotsRule
+	CrawlDelay time.Duration // Crawl-delay 指令，没有时为 0
+}
+
+type robotsRule struct {
+	allow   bool
+	length  int // 原始路径模式的长度，越长越优先
+	pattern *regexp.Regexp
+}
+
+// robotsGroup 一组 User-agent 及其规则
+type robotsGroup struct {
+	agents []string
+	rules  []robotsRule
+	delay  time.Duration
+}
+
+// ParseRobots 解析 robots.txt，返回适用于 agent 的规则
+//
+// 名称包含在 agent 中（不区分大小写）的最长的组优先，没有时使用 * 组。
+// 路径支持 * 通配符和 $ 结尾锚定；同时匹配 Allow 和 Disallow 时，模式更长的一条生效，
+// 长度相同时允许。
+func ParseRobots(data []byte, agent string) *Robots {
+	var groups []*robotsGroup
+	var current *robotsGroup
+	inAgents := false // 上一条指令是 User-agent，连续的 User-agent 属于同一组
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if !inAgents || current == nil {
+				current = &robotsGroup{}
+				groups = append(groups, current)
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+			inAgents = true
+			continue
+		case "allow", "disallow":
+			// 空的 Disallow 表示不限制
+			if current != nil && value != "" {
+				current.rules = append(current.rules, robotsRule{
+					allow:   key == "allow",
+					length:  len(value),
+					pattern: compilePattern(value),
+				})
+			}
+		case "crawl-delay":
+			if current != nil {
+				if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+					current.delay = time.Duration(seconds * float64(time.Second))
+				}
+			}
+		}
+		inAgents = false
+	}
+
+	agent = strings.ToLower(agent)
+	var best *robotsGroup
+	bestLen := -1
+	for _, g := range groups {
+		for _, name := range g.agents {
+			n := -1
+			switch {
+			case name == "*":
+				n = 0
+			case name != "" && strings.Contains(agent, name):
+				n = len(name)
+			}
+			if n > bestLen {
+				best, bestLen = g, n
+			}
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return &Robots{rules: best.rules, CrawlDelay: best.delay}
+}
+
+// compilePattern 将 robots.txt 路径模式转换为从路径开头匹配的正则表达式
+func compilePattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	parts := strings.Split(pattern, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	expr := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
+
+// Allowed 判断路径（含查询参数）是否允许抓取
+func (r *Robots) Allowed(path string) bool {
+	if r == nil {
+		return true
+	}
+	if path == "" {
+		path = "/"
+	}
+	allowed, matched := true, -1
+	for _, rule := range r.rules {
+		if !rule.pattern.MatchString(path) {
+			continue
+		}
+		if rule.length > matched || (rule.length == matched && rule.allow) {
+			allowed, matched = rule.allow, rule.length
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,192 @@
+// Package feed 解析 RSS 2.0 和 Atom 订阅源。
+//
+// 两种格式统一解析为 Feed 和 Entry，条目保持订阅源中的顺序，相对链接按订阅源地址解析为绝对 URL。
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Feed 订阅源
+type Feed struct {
+	Title   string
+	Link    string // 网站地址
+	Entries []Entry
+}
+
+// Entry 订阅源中的条目
+type Entry struct {
+	Title     string
+	Link      string
+	ID        string    // RSS guid 或 Atom id，没有时为空
+	Published time.Time // 发布时间（Atom 没有 published 时为 updated），无法解析时为零值
+}
+
+// Key 返回条目的稳定标识：ID，没有时为链接
+func (e Entry) Key() string {
+	if e.ID != "" {
+		return e.ID
+	}
+	return e.Link
+}
+
+// document RSS 和 Atom 共用的根元素
+type document struct {
+	XMLName xml.Name
+	Channel *rssChannel `xml:"channel"`
+
+	// Atom
+	Title   string      `xml:"title"`
+	Links   []link      `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type rssChannel struct {
+	Title string    `xml:"title"`
+	Links []link    `xml:"link"`
+	Items []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title   string `xml:"title"`
+	Links   []link `xml:"link"`
+	GUID    string `xml:"guid"`
+	PubDate string `xml:"pubDate"`
+	DCDate  string `xml:"http://purl.org/dc/elements/1.1/ date"`
+}
+
+type atomEntry struct {
+	Title     string `xml:"title"`
+	Links     []link `xml:"link"`
+	ID        string `xml:"id"`
+	Published string `xml:"published"`
+	Updated   string `xml:"updated"`
+}
+
+// link RSS 的 <link>URL</link> 或 Atom 的 <link rel="alternate" href="URL"/>
+type link struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Text string `xml:",chardata"`
+}
+
+// pickLink 返回 RSS 链接文本，或 rel 为 alternate（或未指定）的 Atom 链接
+//
+// RSS 频道中常见的 <atom:link rel="self"> 会被跳过。
+func pickLink(links []link) string {
+	for _, l := range links {
+		if text := strings.TrimSpace(l.Text); text != "" {
+			return text
+		}
+	}
+	for _, l := range links {
+		if href := strings.TrimSpace(l.Href); href != "" && (l.Rel == "" || l.Rel == "alternate") {
+			return href
+		}
+	}
+	return ""
+}
+
+// resolve 将链接按订阅源地址解析为绝对 URL，无法解析时原样返回
+func resolve(base *url.URL, link string) string {
+	if base == nil || link == "" {
+		return link
+	}
+	ref, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+	return base.ResolveReference(ref).String()
+}
+
+// Parse 解析 RSS 2.0 或 Atom 文档，feedURL 为订阅源地址，用于解析相对链接
+func Parse(data []byte, feedURL string) (*Feed, error) {
+	base, err := url.Parse(feedURL)
+	if err != nil || !base.IsAbs() {
+		base = nil
+	}
+
+	var doc document
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("解析订阅源失败: %w", err)
+	}
+
+	switch doc.XMLName.Local {
+	case "rss":
+		if doc.Channel == nil {
+			return nil, fmt.Errorf("解析订阅源失败: RSS 中没有 <channel>")
+		}
+		f := &Feed{
+			Title: strings.TrimSpace(doc.Channel.Title),
+			Link:  resolve(base, pickLink(doc.Channel.Links)),
+		}
+		for _, item := range doc.Channel.Items {
+			date := item.PubDate
+			if strings.TrimSpace(date) == "" {
+				date = item.DCDate
+			}
+			published, _ := ParseTime(date)
+			f.Entries = append(f.Entries, Entry{
+				Title:     strings.TrimSpace(item.Title),
+				Link:      resolve(base, pickLink(item.Links)),
+				ID:        strings.TrimSpace(item.GUID),
+				Published: published,
+			})
+		}
+		return f, nil
+	case "feed":
+		f := &Feed{
+			Title: strings.TrimSpace(doc.Title),
+			Link:  resolve(base, pickLink(doc.Links)),
+		}
+		for _, entry := range doc.Entries {
+			date := entry.Published
+			if strings.TrimSpace(date) == "" {
+				date = entry.Updated
+			}
+			published, _ := ParseTime(date)
+			f.Entries = append(f.Entries, Entry{
+				Title:     strings.TrimSpace(entry.Title),
+				Link:      resolve(base, pickLink(entry.Links)),
+				ID:        strings.TrimSpace(entry.ID),
+				Published: published,
+			})
+		}
+		return f, nil
+	default:
+		return nil, fmt.Errorf("不是 RSS 或 Atom 订阅源: <%s>", doc.XMLName.Local)
+	}
+}
+
+// timeLayouts RSS（RFC 822 及常见变体）和 Atom（RFC 3339）使用的时间格式
+var timeLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon, 02 Jan 2006 15:04 -0700",
+	"Mon, 2 Jan 2006 15:04 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 MST",
+	time.RFC822Z,
+	time.RFC822,
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// ParseTime 解析条目的发布时间
+func ParseTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("无效的时间: %q", s)
+}
@@ -0,0 +1,163 @@
+package feed
+
+import (
+	"testing"
+	"time"
+)
+
+const rss = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<channel>
+  <title>Example Blog</title>
+  <atom:link href="https://example.com/feed.xml" rel="self" type="application/rss+xml"/>
+  <link>https://example.com/</link>
+  <item>
+    <title>Second post</title>
+    <link>https://example.com/2</link>
+    <guid isPermaLink="false">post-2</guid>
+    <pubDate>Tue, 07 May 2024 10:00:00 +0800</pubDate>
+  </item>
+  <item>
+    <title><![CDATA[First <b>post</b>]]></title>
+    <link> https://example.com/1 </link>
+    <dc:date>2024-05-01T08:00:00Z</dc:date>
+  </item>
+  <item>
+    <title>Undated</title>
+    <link>https://example.com/0</link>
+    <pubDate>sometime</pubDate>
+  </item>
+</channel>
+</rss>`
+
+const atom = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Example Atom</title>
+  <link href="https://example.com/atom.xml" rel="self"/>
+  <link href="https://example.com/"/>
+  <entry>
+    <title>Atom post</title>
+    <link rel="alternate" href="https://example.com/atom/1"/>
+    <link rel="edit" href="https://example.com/edit/1"/>
+    <id>urn:uuid:1</id>
+    <published>2024-05-02T09:30:00+02:00</published>
+    <updated>2024-05-03T00:00:00Z</updated>
+  </entry>
+  <entry>
+    <title>Updated only</title>
+    <link href="https://example.com/atom/2"/>
+    <id>urn:uuid:2</id>
+    <updated>2024-05-04T00:00:00Z</updated>
+  </entry>
+</feed>`
+
+func TestParseRSS(t *testing.T) {
+	f, err := Parse([]byte(rss), "https://example.com/feed.xml")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if f.Title != "Example Blog" || f.Link != "https://example.com/" {
+		t.Errorf("feed = %q %q", f.Title, f.Link)
+	}
+	want := []Entry{
+		{Title: "Second post", Link: "https://example.com/2", ID: "post-2", Published: time.Date(2024, 5, 7, 2, 0, 0, 0, time.UTC)},
+		{Title: "First <b>post</b>", Link: "https://example.com/1", Published: time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)},
+		{Title: "Undated", Link: "https://example.com/0"},
+	}
+	if len(f.Entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(f.Entries), len(want))
+	}
+	for i, e := range f.Entries {
+		w := want[i]
+		if e.Title != w.Title || e.Link != w.Link || e.ID != w.ID || !e.Published.Equal(w.Published) {
+			t.Errorf("entry %d = %+v, want %+v", i, e, w)
+		}
+	}
+	if f.Entries[0].Key() != "post-2" || f.Entries[1].Key() != "https://example.com/1" {
+		t.Errorf("Key() = %q, %q", f.Entries[0].Key(), f.Entries[1].Key())
+	}
+}
+
+func TestParseAtom(t *testing.T) {
+	f, err := Parse([]byte(atom), "https://example.com/atom.xml")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if f.Title != "Example Atom" || f.Link != "https://example.com/" {
+		t.Errorf("feed = %q %q", f.Title, f.Link)
+	}
+	if len(f.Entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(f.Entries))
+	}
+	e := f.Entries[0]
+	if e.Link != "https://example.com/atom/1" || e.ID != "urn:uuid:1" || !e.Published.Equal(time.Date(2024, 5, 2, 7, 30, 0, 0, time.UTC)) {
+		t.Errorf("entry 0 = %+v", e)
+	}
+	if !f.Entries[1].Published.Equal(time.Date(2024, 5, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("entry 1 published = %v, want updated", f.Entries[1].Published)
+	}
+}
+
+func TestParseRelativeLinks(t *testing.T) {
+	const doc = `<feed xmlns="http://www.w3.org/2005/Atom">
+  <link href="/"/>
+  <entry><link href="/blog/1"/></entry>
+  <entry><link href="2"/></entry>
+  <entry><link href="https://other.com/3"/></entry>
+</feed>`
+	f, err := Parse([]byte(doc), "https://example.com/blog/feed.atom")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if f.Link != "https://example.com/" {
+		t.Errorf("feed link = %q", f.Link)
+	}
+	want := []string{"https://example.com/blog/1", "https://example.com/blog/2", "https://other.com/3"}
+	for i, e := range f.Entries {
+		if e.Link != want[i] {
+			t.Errorf("entry %d link = %q, want %q", i, e.Link, want[i])
+		}
+	}
+
+	// 订阅源地址不是绝对 URL 时保留原始链接
+	f, _ = Parse([]byte(doc), "feed.atom")
+	if f.Entries[0].Link != "/blog/1" {
+		t.Errorf("entry 0 link = %q, want unresolved", f.Entries[0].Link)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, bad := range []string{
+		"<html><body>not a feed</body></html>",
+		`<rss version="2.0"></rss>`,
+		"not xml",
+	} {
+		if _, err := Parse([]byte(bad), ""); err == nil {
+			t.Errorf("Parse(%q) expected error", bad)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	want := time.Date(2024, 5, 7, 10, 0, 0, 0, time.UTC)
+	for _, s := range []string{
+		"Tue, 07 May 2024 10:00:00 +0000",
+		"Tue, 07 May 2024 10:00:00 GMT",
+		"Tue, 7 May 2024 10:00:00 +0000",
+		"7 May 2024 10:00:00 +0000",
+		"2024-05-07T10:00:00Z",
+		" 2024-05-07T12:00:00+02:00 ",
+	} {
+		got, err := ParseTime(s)
+		if err != nil {
+			t.Errorf("ParseTime(%q) error = %v", s, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("ParseTime(%q) = %v, want %v", s, got, want)
+		}
+	}
+	if _, err := ParseTime("yesterday"); err == nil {
+		t.Error("ParseTime(yesterday) expected error")
+	}
+}
@@ -0,0 +1,83 @@
+// Package httpheader 解析、校验和掩码显示用户提供的请求头。
+//
+// api 和 config 都依赖本包，本包只依赖标准库。
+package httpheader
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// sensitiveWords 名称包含这些词的请求头视为敏感，显示时掩码
+var sensitiveWords = []string{"auth", "token", "key", "secret", "password", "cookie", "session", "credential", "signature"}
+
+// sensitiveNames 名称中没有敏感词但值可能包含凭据的请求头（代理 URL 可能带有用户名和密码）
+var sensitiveNames = map[string]bool{
+	"X-Proxy-Url": true,
+}
+
+// Parse 解析 "Name: value" 形式的请求头，返回规范化的名称和值
+func Parse(s string) (string, string, error) {
+	idx := strings.Index(s, ":")
+	if idx < 0 {
+		return "", "", fmt.Errorf("无效的请求头 %q，格式应为 'Name: value'", s)
+	}
+	name := strings.TrimSpace(s[:idx])
+	value := strings.TrimSpace(s[idx+1:])
+	if err := Validate(name, value); err != nil {
+		return "", "", err
+	}
+	return http.CanonicalHeaderKey(name), value, nil
+}
+
+// Validate 校验请求头名称（RFC 7230 token）和值（不含控制字符）
+func Validate(name, value string) error {
+	if name == "" {
+		return fmt.Errorf("请求头名称不能为空")
+	}
+	for _, r := range name {
+		if !isTokenChar(r) {
+			return fmt.Errorf("无效的请求头名称: %q", name)
+		}
+	}
+	for _, r := range value {
+		if (r < 0x20 && r != '\t') || r == 0x7f {
+			return fmt.Errorf("请求头 %s 的值包含控制字符", name)
+		}
+	}
+	return nil
+}
+
+// isTokenChar 判断是否为 RFC 7230 token 字符
+func isTokenChar(r rune) bool {
+	if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+		return true
+	}
+	return strings.ContainsRune("!#$%&'*+-.^_`|~", r)
+}
+
+// IsSensitive 判断请求头的值是否可能包含密钥等敏感信息
+func IsSensitive(name string) bool {
+	if sensitiveNames[http.CanonicalHeaderKey(name)] {
+		return true
+	}
+	lower := strings.ToLower(name)
+	for _, word := range sensitiveWords {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Mask 敏感请求头的值只显示首尾各 4 个字符
+func Mask(name, value string) string {
+	if !IsSensitive(name) {
+		return value
+	}
+	if len(value) <= 8 {
+		return "***"
+	}
+	return value[:4] + "***" + value[len(value)-4:]
+}
@@ -0,0 +1,76 @@
+package input
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 本地文档类型
+const (
+	DocumentHTML = "html"
+	DocumentPDF  = "pdf"
+)
+
+// pdfMagic PDF 文件的起始标记
+var pdfMagic = []byte("%PDF-")
+
+// Document 要直接提交给 Reader 的本地文档
+type Document struct {
+	Path string // 文件路径，标准输入为 -
+	Kind string // DocumentHTML 或 DocumentPDF
+	Data []byte
+}
+
+// LoadDocument 读取本地 HTML 或 PDF 文件，path 为 - 时从 stdin 读取 HTML
+//
+// 以 %PDF- 开头或扩展名为 .pdf 的文件视为 PDF，其余视为 HTML。
+func LoadDocument(path string, stdin io.Reader) (*Document, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, fmt.Errorf("文件为空: %s", path)
+	}
+
+	kind := DocumentHTML
+	if bytes.HasPrefix(data, pdfMagic) || strings.EqualFold(filepath.Ext(path), ".pdf") {
+		kind = DocumentPDF
+	}
+	return &Document{Path: path, Kind: kind, Data: data}, nil
+}
+
+// LocalPath 判断批量输入中的一项是否为本地文件，是则返回文件路径
+//
+// 只有 file:// URL 和以 /、./、../ 开头的路径视为本地文件（文件不存在时由读取报错）；
+// 其余项（如省略协议的 example.com）一律按 URL 读取，不会因为当前目录存在同名文件而改变含义。
+// 当前目录下的文件需要写成 ./page.html。
+func LocalPath(s string) (string, bool) {
+	if strings.HasPrefix(s, "file://") {
+		u, err := url.Parse(s)
+		if err != nil || u.Path == "" {
+			return "", false
+		}
+		return u.Path, true
+	}
+	if strings.Contains(s, "://") {
+		return "", false
+	}
+	for _, prefix := range []string{"/", "./", "../"} {
+		if strings.HasPrefix(s, prefix) {
+			return s, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,85 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadDocument(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"page.html":  "<html><body>hi</body></html>",
+		"report.pdf": "not really a pdf",
+		"export.bin": "%PDF-1.7\n...",
+		"empty.html": " \n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		wantKind string
+		wantErr  bool
+	}{
+		{"page.html", DocumentHTML, false},
+		{"report.pdf", DocumentPDF, false},
+		{"export.bin", DocumentPDF, false},
+		{"empty.html", "", true},
+		{"missing.html", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := LoadDocument(filepath.Join(dir, tt.name), nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadDocument() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && doc.Kind != tt.wantKind {
+				t.Errorf("Kind = %s, want %s", doc.Kind, tt.wantKind)
+			}
+		})
+	}
+
+	doc, err := LoadDocument("-", strings.NewReader("<p>stdin</p>"))
+	if err != nil {
+		t.Fatalf("LoadDocument(-) failed: %v", err)
+	}
+	if doc.Kind != DocumentHTML || string(doc.Data) != "<p>stdin</p>" {
+		t.Errorf("unexpected stdin document: %+v", doc)
+	}
+}
+
+func TestLocalPath(t *testing.T) {
+	// 当前目录下的同名文件不会让裸项变成本地文件
+	t.Chdir(t.TempDir())
+	for _, name := range []string{"page.html", "example.com"} {
+		if err := os.WriteFile(name, []byte("<p></p>"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{"https://example.com/page.html", "", false},
+		{"example.com", "", false},
+		{"page.html", "", false},
+		{"./page.html", "./page.html", true},
+		{"../page.html", "../page.html", true},
+		{"./missing.html", "./missing.html", true},
+		{"/tmp/report.pdf", "/tmp/report.pdf", true},
+		{"file:///tmp/report.pdf", "/tmp/report.pdf", true},
+	}
+	for _, tt := range tests {
+		got, ok := LocalPath(tt.input)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("LocalPath(%q) = %q, %t; want %q, %t", tt.input, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
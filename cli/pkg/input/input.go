@@ -0,0 +1,354 @@
+// Package input 解析批量读取的输入列表。
+//
+// 支持三种格式：
+//
+//   - lines：每行一个 URL，忽略空行和以 # 开头的注释行
+//   - csv：首行为表头，必须包含 url 列
+//   - jsonl：每行一个 JSON 对象，必须包含 url 字段
+//
+// CSV 和 JSONL 的每一行可以覆盖读取选项（format、target_selector、
+// wait_for_selector、cookie、no_cache、post、headers），其余列作为用户元数据原样回显。
+// CSV 中以 header: 开头的列作为请求头，如 header:X-Locale。
+//
+// 输入项也可以是本地 HTML 或 PDF 文件（见 LocalPath 和 LoadDocument），其内容直接提交给 Reader。
+// 本地文件必须写成 file:// URL 或以 /、./、../ 开头的路径。
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/geekjourneyx/jina-cli/cli/pkg/httpheader"
+)
+
+// Format 输入格式
+type Format string
+
+// 支持的输入格式
+const (
+	FormatAuto  Format = "auto"
+	FormatLines Format = "lines"
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+// headerColumnPrefix CSV 中表示请求头的列名前缀
+const headerColumnPrefix = "header:"
+
+// utf8BOM 表格软件导出的 CSV 可能带有 UTF-8 BOM
+const utf8BOM = "\ufeff"
+
+// Item 单个待读取的 URL 及其覆盖选项
+//
+// 指针字段为 nil 表示未覆盖，使用命令行参数的值。
+type Item struct {
+	URL             string
+	Format          string
+	TargetSelector  string
+	WaitForSelector string
+	Cookie          string
+	Headers         map[string]string
+	NoCache         *bool
+	Post            *bool
+	Meta            map[string]interface{}
+
+	// AllowLocal 为 true 时本项可以是本地文件（见 LocalPath），只有用户提供的 --file 输入才设置，
+	// sitemap 等远程来源的 URL 不能指向本地文件
+	AllowLocal bool
+}
+
+// ParseFormat 解析输入格式名称
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case "":
+		return FormatAuto, nil
+	case FormatAuto, FormatLines, FormatCSV, FormatJSONL:
+		return f, nil
+	case "ndjson":
+		return FormatJSONL, nil
+	case "txt", "text":
+		return FormatLines, nil
+	default:
+		return "", fmt.Errorf("无效的输入格式: %s（可选 auto、lines、csv、jsonl）", s)
+	}
+}
+
+// DetectFormat 根据文件扩展名或内容推断输入格式
+//
+// 扩展名优先；标准输入或未知扩展名时，首个非空行以 { 开头视为 JSONL，
+// 包含 url 列的逗号分隔表头视为 CSV，否则为逐行 URL。
+func DetectFormat(name string, data []byte) Format {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return FormatCSV
+	case ".jsonl", ".ndjson":
+		return FormatJSONL
+	}
+
+	line := firstLine(data)
+	if strings.HasPrefix(line, "{") {
+		return FormatJSONL
+	}
+	if strings.Contains(line, ",") {
+		for _, field := range strings.Split(line, ",") {
+			if normalizeKey(strings.Trim(field, ` "`)) == "url" {
+				return FormatCSV
+			}
+		}
+	}
+	return FormatLines
+}
+
+// Parse 按指定格式解析输入，format 为 auto 时根据 name 和内容推断
+func Parse(data []byte, format Format, name string) ([]Item, error) {
+	if format == FormatAuto || format == "" {
+		format = DetectFormat(name, data)
+	}
+
+	switch format {
+	case FormatLines:
+		return parseLines(data), nil
+	case FormatCSV:
+		return parseCSV(data)
+	case FormatJSONL:
+		return parseJSONL(data)
+	default:
+		return nil, fmt.Errorf("无效的输入格式: %s", format)
+	}
+}
+
+// parseLines 解析每行一个 URL 的列表
+func parseLines(data []byte) []Item {
+	lines := strings.Split(string(data), "\n")
+	items := make([]Item, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		// 跳过空行和注释行
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		items = append(items, Item{URL: line})
+	}
+	return items
+}
+
+// parseCSV 解析带表头的 CSV
+func parseCSV(data []byte) ([]Item, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte(utf8BOM))))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("解析 CSV 表头失败: %w", err)
+	}
+
+	urlColumn := -1
+	for i, name := range header {
+		header[i] = strings.TrimSpace(name)
+		if normalizeKey(header[i]) == "url" {
+			urlColumn = i
+		}
+	}
+	if urlColumn < 0 {
+		return nil, fmt.Errorf("CSV 缺少 url 列")
+	}
+
+	var items []Item
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("解析 CSV 失败: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+
+		if urlColumn >= len(row) || strings.TrimSpace(row[urlColumn]) == "" {
+			continue
+		}
+
+		var item Item
+		for i, value := range row {
+			if i >= len(header) {
+				break
+			}
+			name := header[i]
+			if strings.HasPrefix(strings.ToLower(name), headerColumnPrefix) {
+				if value != "" {
+					if err := item.setHeader(strings.TrimSpace(name[len(headerColumnPrefix):]), value); err != nil {
+						return nil, fmt.Errorf("CSV 第 %d 行: %w", line, err)
+					}
+				}
+				continue
+			}
+			if err := item.set(name, value); err != nil {
+				return nil, fmt.Errorf("CSV 第 %d 行: %w", line, err)
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// parseJSONL 解析每行一个 JSON 对象的输入
+func parseJSONL(data []byte) ([]Item, error) {
+	var items []Item
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		var fields map[string]interface{}
+		dec := json.NewDecoder(strings.NewReader(text))
+		dec.UseNumber()
+		if err := dec.Decode(&fields); err != nil {
+			return nil, fmt.Errorf("JSONL 第 %d 行: 无效的 JSON: %w", line, err)
+		}
+
+		var item Item
+		for key, value := range fields {
+			if normalizeKey(key) == "headers" {
+				headers, ok := value.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("JSONL 第 %d 行: headers 必须是对象", line)
+				}
+				for name, v := range headers {
+					// 请求头的值只能是字符串、数字或布尔值
+					switch v.(type) {
+					case string, json.Number, bool:
+					default:
+						return nil, fmt.Errorf("JSONL 第 %d 行: headers.%s 必须是字符串、数字或布尔值", line, name)
+					}
+					if err := item.setHeader(name, fmt.Sprint(v)); err != nil {
+						return nil, fmt.Errorf("JSONL 第 %d 行: %w", line, err)
+					}
+				}
+				continue
+			}
+			if err := item.setValue(key, value); err != nil {
+				return nil, fmt.Errorf("JSONL 第 %d 行: %w", line, err)
+			}
+		}
+		if item.URL == "" {
+			return nil, fmt.Errorf("JSONL 第 %d 行: 缺少 url 字段", line)
+		}
+		items = append(items, item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取 JSONL 失败: %w", err)
+	}
+	return items, nil
+}
+
+// set 设置 CSV 列的值，空值表示不覆盖
+func (item *Item) set(name, value string) error {
+	value = strings.TrimSpace(value)
+	switch normalizeKey(name) {
+	case "url":
+		item.URL = value
+	case "format":
+		item.Format = value
+	case "target_selector":
+		item.TargetSelector = value
+	case "wait_for_selector":
+		item.WaitForSelector = value
+	case "cookie":
+		item.Cookie = value
+	case "no_cache":
+		return setBool(&item.NoCache, name, value)
+	case "post":
+		return setBool(&item.Post, name, value)
+	default:
+		item.setMeta(name, value)
+	}
+	return nil
+}
+
+// setValue 设置 JSONL 字段的值，字符串以外的选项值按字符串处理
+func (item *Item) setValue(key string, value interface{}) error {
+	switch normalizeKey(key) {
+	case "url", "format", "target_selector", "wait_for_selector", "cookie":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s 必须是字符串", key)
+		}
+		return item.set(key, s)
+	case "no_cache", "post":
+		switch v := value.(type) {
+		case bool:
+			return item.set(key, strconv.FormatBool(v))
+		case string:
+			return item.set(key, v)
+		default:
+			return fmt.Errorf("%s 必须是布尔值", key)
+		}
+	default:
+		item.setMeta(key, value)
+	}
+	return nil
+}
+
+// setHeader 校验并设置请求头
+func (item *Item) setHeader(name, value string) error {
+	if err := httpheader.Validate(name, value); err != nil {
+		return err
+	}
+	if item.Headers == nil {
+		item.Headers = make(map[string]string)
+	}
+	item.Headers[name] = value
+	return nil
+}
+
+// setMeta 记录用户元数据
+func (item *Item) setMeta(key string, value interface{}) {
+	if item.Meta == nil {
+		item.Meta = make(map[string]interface{})
+	}
+	item.Meta[key] = value
+}
+
+// setBool 解析布尔值，空值表示不覆盖
+func setBool(dst **bool, name, value string) error {
+	if value == "" {
+		return nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("%s 的值无效: %s", name, value)
+	}
+	*dst = &b
+	return nil
+}
+
+// normalizeKey 统一列名：小写，连字符替换为下划线
+func normalizeKey(key string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+}
+
+// firstLine 返回第一个非空、非注释行
+func firstLine(data []byte) string {
+	for _, line := range strings.Split(string(bytes.TrimPrefix(data, []byte(utf8BOM))), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,166 @@
+package input
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Format
+		wantErr bool
+	}{
+		{"", FormatAuto, false},
+		{"auto", FormatAuto, false},
+		{"CSV", FormatCSV, false},
+		{"jsonl", FormatJSONL, false},
+		{"ndjson", FormatJSONL, false},
+		{"lines", FormatLines, false},
+		{"xml", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFormat(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseFormat(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want Format
+	}{
+		{"urls.csv", "https://a.com\n", FormatCSV},
+		{"urls.jsonl", "https://a.com\n", FormatJSONL},
+		{"-", "{\"url\":\"https://a.com\"}\n", FormatJSONL},
+		{"-", "# comment\nurl,tag\nhttps://a.com,x\n", FormatCSV},
+		{"-", "\ufeffURL,tag\nhttps://a.com,x\n", FormatCSV},
+		{"urls.txt", "https://a.com\nhttps://b.com\n", FormatLines},
+		{"-", "https://a.com/?a=1,2\n", FormatLines},
+	}
+
+	for _, tt := range tests {
+		if got := DetectFormat(tt.name, []byte(tt.data)); got != tt.want {
+			t.Errorf("DetectFormat(%q, %q) = %q, want %q", tt.name, tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestParse_Lines(t *testing.T) {
+	items, err := Parse([]byte("https://a.com\n\n# comment\n  https://b.com  \n"), FormatAuto, "urls.txt")
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	want := []Item{{URL: "https://a.com"}, {URL: "https://b.com"}}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("Parse() = %+v, want %+v", items, want)
+	}
+}
+
+func TestParse_CSV(t *testing.T) {
+	data := "url,format,target-selector,no_cache,post,header:X-Locale,tag\n" +
+		"https://a.com,html,#main,true,,en-US,docs\n" +
+		"https://b.com,,,,false,,\n" +
+		",,,,,,skipped\n"
+
+	items, err := Parse([]byte(data), FormatCSV, "-")
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("Parse() returned %d items, want 2", len(items))
+	}
+
+	a := items[0]
+	if a.URL != "https://a.com" || a.Format != "html" || a.TargetSelector != "#main" {
+		t.Errorf("item[0] = %+v", a)
+	}
+	if a.NoCache == nil || !*a.NoCache || a.Post != nil {
+		t.Errorf("item[0] NoCache/Post = %v/%v", a.NoCache, a.Post)
+	}
+	if a.Headers["X-Locale"] != "en-US" {
+		t.Errorf("item[0].Headers = %v", a.Headers)
+	}
+	if a.Meta["tag"] != "docs" {
+		t.Errorf("item[0].Meta = %v", a.Meta)
+	}
+
+	b := items[1]
+	if b.Post == nil || *b.Post || b.NoCache != nil || b.Headers != nil {
+		t.Errorf("item[1] = %+v", b)
+	}
+}
+
+func TestParse_CSVErrors(t *testing.T) {
+	if _, err := Parse([]byte("link,tag\nhttps://a.com,x\n"), FormatCSV, "-"); err == nil {
+		t.Error("expected error for missing url column")
+	}
+	if _, err := Parse([]byte("url,post\nhttps://a.com,maybe\n"), FormatCSV, "-"); err == nil {
+		t.Error("expected error for invalid boolean")
+	}
+	if _, err := Parse([]byte("url,header:X Foo\nhttps://a.com,bar\n"), FormatCSV, "-"); err == nil {
+		t.Error("expected error for invalid header name")
+	}
+	if _, err := Parse([]byte("url,header:X-Foo\nhttps://a.com,\"bar\rX-Evil: 1\"\n"), FormatCSV, "-"); err == nil || !strings.Contains(err.Error(), "第 2 行") {
+		t.Errorf("expected line error for header value with control characters, got %v", err)
+	}
+}
+
+func TestParse_JSONL(t *testing.T) {
+	data := `{"url":"https://a.com","wait_for_selector":".ready","cookie":"a=1","headers":{"X-Foo":"bar"},"post":true,"id":42,"tags":["x"]}
+# comment
+
+{"url":"https://b.com","no-cache":"false"}
+`
+	items, err := Parse([]byte(data), FormatAuto, "-")
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("Parse() returned %d items, want 2", len(items))
+	}
+
+	a := items[0]
+	if a.WaitForSelector != ".ready" || a.Cookie != "a=1" || a.Headers["X-Foo"] != "bar" {
+		t.Errorf("item[0] = %+v", a)
+	}
+	if a.Post == nil || !*a.Post {
+		t.Errorf("item[0].Post = %v", a.Post)
+	}
+	if a.Meta["id"] != json.Number("42") || !reflect.DeepEqual(a.Meta["tags"], []interface{}{"x"}) {
+		t.Errorf("item[0].Meta = %v", a.Meta)
+	}
+
+	if b := items[1]; b.NoCache == nil || *b.NoCache {
+		t.Errorf("item[1].NoCache = %v", b.NoCache)
+	}
+}
+
+func TestParse_JSONLErrors(t *testing.T) {
+	tests := []string{
+		`{"url":"https://a.com"` + "\n",
+		`{"tag":"x"}` + "\n",
+		`{"url":"https://a.com","headers":"X-Foo: bar"}` + "\n",
+		`{"url":"https://a.com","post":1}` + "\n",
+		`{"url":"https://a.com","headers":{"X Foo":"bar"}}` + "\n",
+		`{"url":"https://a.com","headers":{"X-Foo":"bar\nX-Evil: 1"}}` + "\n",
+		`{"url":"https://a.com","headers":{"X-Foo":{"a":1}}}` + "\n",
+		`{"url":"https://a.com","headers":{"X-Foo":["a"]}}` + "\n",
+		`{"url":"https://a.com","headers":{"X-Foo":null}}` + "\n",
+	}
+	for _, data := range tests {
+		if _, err := Parse([]byte(data), FormatJSONL, "-"); err == nil {
+			t.Errorf("Parse(%q) expected error", data)
+		}
+	}
+}
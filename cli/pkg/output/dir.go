@@ -0,0 +1,254 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// ManifestFile 输出目录中的清单文件名
+const ManifestFile = "manifest.json"
+
+// maxSlugLength 文件名（不含扩展名）的最大字符数
+const maxSlugLength = 80
+
+// ManifestEntry 清单中单个 URL 的记录
+type ManifestEntry struct {
+	File      string    `json:"file,omitempty"`
+	Status    string    `json:"status"` // ok 或 error
+	Title     string    `json:"title,omitempty"`
+	Bytes     int64     `json:"bytes"`
+	Width     int       `json:"width,omitempty"`  // 图片宽度（仅截图）
+	Height    int       `json:"height,omitempty"` // 图片高度（仅截图）
+	Timestamp time.Time `json:"timestamp"`
+	Error     string    `json:"error,omitempty"`
+	Code      string    `json:"code,omitempty"`
+}
+
+// 清单记录状态
+const (
+	StatusOK    = "ok"
+	StatusError = "error"
+)
+
+// DirWriter 将每个结果写入输出目录中的单独文件，并维护 URL 到文件的清单
+//
+// 文件名取标题（没有标题时取 URL）的 slug，重名时追加 -2、-3 等后缀。
+// 目录中已有清单时会加载，同一 URL 再次写入时覆盖原文件。
+// 可被多个 goroutine 并发调用。
+type DirWriter struct {
+	mu       sync.Mutex
+	dir      string
+	manifest map[string]ManifestEntry
+	used     map[string]bool
+}
+
+// NewDirWriter 创建输出目录写入器，目录不存在时创建
+func NewDirWriter(dir string) (*DirWriter, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("创建输出目录失败: %w", err)
+	}
+
+	d := &DirWriter{
+		dir:      dir,
+		manifest: make(map[string]ManifestEntry),
+		used:     map[string]bool{ManifestFile: true},
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err == nil {
+		if err := json.Unmarshal(data, &d.manifest); err != nil {
+			return nil, fmt.Errorf("解析清单文件失败: %w", err)
+		}
+		for _, entry := range d.manifest {
+			if entry.File != "" {
+				d.used[entry.File] = true
+			}
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("读取清单文件失败: %w", err)
+	}
+
+	return d, nil
+}
+
+// Dir 返回输出目录
+func (d *DirWriter) Dir() string {
+	return d.dir
+}
+
+// Save 为 URL 创建文件并通过 write 写入内容，返回清单记录
+func (d *DirWriter) Save(rawURL, title, ext string, write func(w io.Writer) error) (ManifestEntry, error) {
+	name := d.reserve(rawURL, title, ext)
+	path := filepath.Join(d.dir, name)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return ManifestEntry{}, fmt.Errorf("创建文件失败: %w", err)
+	}
+	cw := &countingWriter{w: f}
+	werr := write(cw)
+	cerr := f.Close()
+	if werr != nil {
+		return ManifestEntry{}, fmt.Errorf("写入文件失败: %w", werr)
+	}
+	if cerr != nil {
+		return ManifestEntry{}, fmt.Errorf("写入文件失败: %w", cerr)
+	}
+
+	entry := ManifestEntry{
+		File:      name,
+		Status:    StatusOK,
+		Title:     title,
+		Bytes:     cw.n,
+		Timestamp: time.Now().UTC(),
+	}
+	d.Record(rawURL, entry)
+	return entry, nil
+}
+
+// SaveMarkdown 将结果按 Markdown 输出格式写入文件
+func (d *DirWriter) SaveMarkdown(rawURL, title string, data map[string]interface{}) (ManifestEntry, error) {
+	return d.Save(rawURL, title, ".md", func(w io.Writer) error {
+		return NewMarkdownWriter(w).Print(data)
+	})
+}
+
+// SaveImage 将截图写入文件，清单记录中包含图片尺寸
+func (d *DirWriter) SaveImage(rawURL, title, ext string, data []byte, width, height int) (ManifestEntry, error) {
+	entry, err := d.Save(rawURL, title, ext, func(w io.Writer) error {
+		_, werr := w.Write(data)
+		return werr
+	})
+	if err != nil {
+		return entry, err
+	}
+	entry.Width, entry.Height = width, height
+	d.Record(rawURL, entry)
+	return entry, nil
+}
+
+// Record 记录 URL 的清单条目（如失败记录）
+func (d *DirWriter) Record(rawURL string, entry ManifestEntry) {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now().UTC()
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.manifest[rawURL] = entry
+}
+
+// Close 写入清单文件
+func (d *DirWriter) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	data, err := json.MarshalIndent(d.manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("编码清单文件失败: %w", err)
+	}
+
+	// 先写临时文件再重命名，避免中断时留下不完整的清单
+	path := filepath.Join(d.dir, ManifestFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("写入清单文件失败: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("写入清单文件失败: %w", err)
+	}
+	return nil
+}
+
+// reserve 为 URL 分配文件名，同一 URL 复用已有文件名
+func (d *DirWriter) reserve(rawURL, title, ext string) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if entry, ok := d.manifest[rawURL]; ok && entry.File != "" && filepath.Ext(entry.File) == ext {
+		return entry.File
+	}
+
+	base := Slugify(title)
+	if base == "" {
+		base = slugifyURL(rawURL)
+	}
+	if base == "" {
+		base = "page"
+	}
+
+	name := base + ext
+	for n := 2; d.used[name]; n++ {
+		name = fmt.Sprintf("%s-%d%s", base, n, ext)
+	}
+	d.used[name] = true
+	return name
+}
+
+// Slugify 将文本转换为适合作为文件名的 slug
+//
+// 保留字母和数字（包括中文等非 ASCII 字符），其余字符替换为连字符。
+func Slugify(s string) string {
+	var b strings.Builder
+	count := 0
+	lastDash := true
+	for _, r := range strings.ToLower(s) {
+		if count >= maxSlugLength {
+			break
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			lastDash = false
+			count++
+			continue
+		}
+		if !lastDash {
+			b.WriteRune('-')
+			lastDash = true
+			count++
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
+
+// slugifyURL 使用 URL 的主机名和路径生成 slug
+func slugifyURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return Slugify(rawURL)
+	}
+	return Slugify(u.Host + " " + u.Path)
+}
+
+// ExtensionForFormat 返回响应格式对应的文件扩展名
+func ExtensionForFormat(format string) string {
+	switch format {
+	case "html":
+		return ".html"
+	case "text":
+		return ".txt"
+	case "screenshot", "pageshot":
+		return ".png"
+	default:
+		return ".md"
+	}
+}
+
+// countingWriter 统计写入字节数
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
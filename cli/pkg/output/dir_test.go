@@ -0,0 +1,157 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Hello, World!", "hello-world"},
+		{"  Go 1.22 Release Notes  ", "go-1-22-release-notes"},
+		{"中文 标题", "中文-标题"},
+		{"---", ""},
+		{"", ""},
+		{strings.Repeat("a", 100), strings.Repeat("a", maxSlugLength)},
+	}
+
+	for _, tt := range tests {
+		if got := Slugify(tt.input); got != tt.want {
+			t.Errorf("Slugify(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestExtensionForFormat(t *testing.T) {
+	tests := map[string]string{
+		"":           ".md",
+		"markdown":   ".md",
+		"html":       ".html",
+		"text":       ".txt",
+		"screenshot": ".png",
+		"pageshot":   ".png",
+	}
+	for format, want := range tests {
+		if got := ExtensionForFormat(format); got != want {
+			t.Errorf("ExtensionForFormat(%q) = %q, want %q", format, got, want)
+		}
+	}
+}
+
+func TestDirWriter_SaveAndManifest(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "pages")
+	d, err := NewDirWriter(dir)
+	if err != nil {
+		t.Fatalf("NewDirWriter() failed: %v", err)
+	}
+
+	entry, err := d.SaveMarkdown("https://a.com/1", "Same Title", map[string]interface{}{
+		"title":   "Same Title",
+		"url":     "https://a.com/1",
+		"content": "body one",
+	})
+	if err != nil {
+		t.Fatalf("SaveMarkdown() failed: %v", err)
+	}
+	if entry.File != "same-title.md" || entry.Status != StatusOK {
+		t.Errorf("entry = %+v", entry)
+	}
+
+	// 同名标题追加后缀
+	entry2, err := d.Save("https://a.com/2", "Same Title", ".md", func(w io.Writer) error {
+		_, err := io.WriteString(w, "body two")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	if entry2.File != "same-title-2.md" || entry2.Bytes != 8 {
+		t.Errorf("entry2 = %+v", entry2)
+	}
+
+	// 没有标题时使用 URL
+	entry3, err := d.Save("https://b.com/docs/page", "", ".html", func(w io.Writer) error { return nil })
+	if err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	if entry3.File != "b-com-docs-page.html" {
+		t.Errorf("entry3.File = %q", entry3.File)
+	}
+
+	d.Record("https://c.com", ManifestEntry{Status: StatusError, Error: "boom", Code: "TIMEOUT"})
+	if err := d.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "same-title.md"))
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	if !strings.Contains(string(content), "# Same Title") || !strings.Contains(string(content), "body one") {
+		t.Errorf("markdown file = %q", content)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		t.Fatalf("ReadFile(manifest) failed: %v", err)
+	}
+	var manifest map[string]ManifestEntry
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+	if len(manifest) != 4 {
+		t.Fatalf("manifest has %d entries, want 4", len(manifest))
+	}
+	if got := manifest["https://c.com"]; got.Status != StatusError || got.Code != "TIMEOUT" || got.Timestamp.IsZero() {
+		t.Errorf("error entry = %+v", got)
+	}
+
+	// 重新打开后，同一 URL 复用文件名，新 URL 不覆盖已有文件
+	d, err = NewDirWriter(dir)
+	if err != nil {
+		t.Fatalf("NewDirWriter() reopen failed: %v", err)
+	}
+	again, _ := d.Save("https://a.com/2", "Same Title", ".md", func(w io.Writer) error { return nil })
+	if again.File != "same-title-2.md" {
+		t.Errorf("reused file = %q, want same-title-2.md", again.File)
+	}
+	other, _ := d.Save("https://a.com/3", "Same Title", ".md", func(w io.Writer) error { return nil })
+	if other.File != "same-title-3.md" {
+		t.Errorf("new file = %q, want same-title-3.md", other.File)
+	}
+}
+
+func TestDirWriter_SaveImage(t *testing.T) {
+	dir := t.TempDir()
+	d, err := NewDirWriter(dir)
+	if err != nil {
+		t.Fatalf("NewDirWriter() failed: %v", err)
+	}
+
+	entry, err := d.SaveImage("https://a.com", "Example", ".png", []byte("png-bytes"), 1280, 720)
+	if err != nil {
+		t.Fatalf("SaveImage() failed: %v", err)
+	}
+	if entry.File != "example.png" || entry.Bytes != 9 || entry.Width != 1280 || entry.Height != 720 {
+		t.Errorf("entry = %+v", entry)
+	}
+	if err := d.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(dir, ManifestFile))
+	var manifest map[string]ManifestEntry
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+	if got := manifest["https://a.com"]; got.Width != 1280 || got.Height != 720 {
+		t.Errorf("manifest entry = %+v", got)
+	}
+}
@@ -2,13 +2,22 @@
 //
 // 所有输出为 JSON 或 Markdown 格式，包含 success 字段表示操作是否成功。
 // 成功时包含 data 字段，失败时包含 error 字段。
+//
+// NDJSON 格式每行一个 JSON 对象，结果完成后立即写出，最后写出汇总记录，
+// 适合批量处理和流式消费。
+//
+// 如果错误实现了 ErrorCode() string，code 字段为该错误码；
+// 如果错误实现了 ExitCode() int，进程以该退出码退出，否则退出码为 1。
 package output
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"sync"
 )
 
 // OutputFormat 输出格式类型
@@ -19,6 +28,8 @@ const (
 	FormatJSON OutputFormat = "json"
 	// FormatMarkdown Markdown 格式
 	FormatMarkdown OutputFormat = "markdown"
+	// FormatNDJSON 每行一个 JSON 对象的流式格式
+	FormatNDJSON OutputFormat = "ndjson"
 )
 
 // SuccessResponse 成功响应
@@ -34,12 +45,58 @@ type ErrorResponse struct {
 	Code    string `json:"code,omitempty"`
 }
 
+// codedError 携带稳定错误码的错误
+type codedError interface {
+	ErrorCode() string
+}
+
+// exitCoder 携带进程退出码的错误
+type exitCoder interface {
+	ExitCode() int
+}
+
+// ErrorCode 返回错误携带的错误码，没有时返回空字符串
+func ErrorCode(err error) string {
+	var c codedError
+	if errors.As(err, &c) {
+		return c.ErrorCode()
+	}
+	return ""
+}
+
+// ExitCode 返回错误对应的进程退出码，默认为 1
+func ExitCode(err error) int {
+	var c exitCoder
+	if errors.As(err, &c) {
+		return c.ExitCode()
+	}
+	return 1
+}
+
+// newErrorResponse 构建错误响应
+func newErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{
+		Success: false,
+		Error:   err.Error(),
+		Code:    ErrorCode(err),
+	}
+}
+
 // Output 输出接口
 type Output interface {
 	Print(data interface{}) error
 	Error(err error) error
 }
 
+// StreamOutput 支持逐条输出结果的输出处理器
+type StreamOutput interface {
+	Output
+	// PrintItem 立即输出单条结果，index 为结果在输入中的位置
+	PrintItem(index int, data interface{}) error
+	// PrintSummary 输出汇总记录
+	PrintSummary(data interface{}) error
+}
+
 // JSONOutput JSON 输出
 type JSONOutput struct {
 	pretty bool
@@ -61,14 +118,10 @@ func (j *JSONOutput) Print(data interface{}) error {
 
 // Error 输出错误
 func (j *JSONOutput) Error(err error) error {
-	resp := ErrorResponse{
-		Success: false,
-		Error:   err.Error(),
-	}
-	if err := j.printJSON(resp); err != nil {
-		return err
+	if perr := j.printJSON(newErrorResponse(err)); perr != nil {
+		return perr
 	}
-	os.Exit(1)
+	os.Exit(ExitCode(err))
 	return nil
 }
 
@@ -87,6 +140,7 @@ func (j *JSONOutput) printJSON(v interface{}) error {
 // MarkdownOutput Markdown 输出
 type MarkdownOutput struct {
 	outputFile *os.File
+	writer     io.Writer
 }
 
 // NewMarkdownOutput 创建 Markdown 输出
@@ -102,6 +156,11 @@ func NewMarkdownOutput(outputFile string) (*MarkdownOutput, error) {
 	return m, nil
 }
 
+// NewMarkdownWriter 创建写入指定 Writer 的 Markdown 输出，不负责关闭 Writer
+func NewMarkdownWriter(w io.Writer) *MarkdownOutput {
+	return &MarkdownOutput{writer: w}
+}
+
 // Print 输出数据
 func (m *MarkdownOutput) Print(data interface{}) error {
 	// 根据数据类型格式化
@@ -122,11 +181,15 @@ func (m *MarkdownOutput) Print(data interface{}) error {
 
 // Error 输出错误
 func (m *MarkdownOutput) Error(err error) error {
-	fmt.Fprintf(m.getWriter(), "**Error**: %s\n", err.Error())
+	if code := ErrorCode(err); code != "" {
+		fmt.Fprintf(m.getWriter(), "**Error** (%s): %s\n", code, err.Error())
+	} else {
+		fmt.Fprintf(m.getWriter(), "**Error**: %s\n", err.Error())
+	}
 	if m.outputFile != nil {
 		m.outputFile.Close()
 	}
-	os.Exit(1)
+	os.Exit(ExitCode(err))
 	return nil
 }
 
@@ -134,12 +197,30 @@ func (m *MarkdownOutput) getWriter() io.Writer {
 	if m.outputFile != nil {
 		return m.outputFile
 	}
+	if m.writer != nil {
+		return m.writer
+	}
 	return os.Stdout
 }
 
 func (m *MarkdownOutput) printMapAsMarkdown(data map[string]interface{}) {
 	w := m.getWriter()
 
+	// 搜索结果：以查询为标题输出结果列表
+	if results, ok := data["results"].([]map[string]interface{}); ok {
+		if query, ok := data["query"].(string); ok {
+			fmt.Fprintf(w, "# %s\n\n", query)
+		} else if queries, ok := data["queries"].([]string); ok {
+			fmt.Fprintf(w, "# %s\n\n", strings.Join(queries, " | "))
+		}
+		items := make([]interface{}, len(results))
+		for i, r := range results {
+			items[i] = r
+		}
+		m.printSliceAsMarkdown(items)
+		return
+	}
+
 	// 尝试提取常见字段
 	if title, ok := data["title"].(string); ok {
 		fmt.Fprintf(w, "# %s\n\n", title)
@@ -161,6 +242,13 @@ func (m *MarkdownOutput) printSliceAsMarkdown(data []interface{}) {
 	w := m.getWriter()
 	for i, item := range data {
 		if m, ok := item.(map[string]interface{}); ok {
+			_, hasContent := m["content"]
+			_, hasError := m["error"]
+			if !hasContent && !hasError {
+				// 没有内容的结果（如 search --no-content）输出紧凑列表
+				printCompactItem(w, i, m)
+				continue
+			}
 			if title, ok := m["title"].(string); ok {
 				fmt.Fprintf(w, "## %d. %s\n", i+1, title)
 				if url, ok := m["url"].(string); ok {
@@ -179,6 +267,33 @@ func (m *MarkdownOutput) printSliceAsMarkdown(data []interface{}) {
 	}
 }
 
+// printCompactItem 输出一行 "1. [标题](URL) (发布时间或日期) — 描述"，没有标题时显示 URL
+func printCompactItem(w io.Writer, i int, item map[string]interface{}) {
+	title, _ := item["title"].(string)
+	url, _ := item["url"].(string)
+	if title == "" && url == "" {
+		return
+	}
+	if title == "" {
+		title = url
+	}
+	line := fmt.Sprintf("%d. %s", i+1, title)
+	if url != "" {
+		line = fmt.Sprintf("%d. [%s](<%s>)", i+1, title, url)
+	}
+	// 订阅源条目为 published，搜索结果为 date
+	for _, key := range []string{"published", "date"} {
+		if date, ok := item[key].(string); ok && date != "" {
+			line += " (" + date + ")"
+			break
+		}
+	}
+	if desc, ok := item["description"].(string); ok && desc != "" {
+		line += " — " + desc
+	}
+	fmt.Fprintln(w, line)
+}
+
 // Close 关闭输出文件
 func (m *MarkdownOutput) Close() error {
 	if m.outputFile != nil {
@@ -187,6 +302,101 @@ func (m *MarkdownOutput) Close() error {
 	return nil
 }
 
+// 流式记录类型
+const (
+	RecordResult  = "result"
+	RecordSummary = "summary"
+	RecordError   = "error"
+)
+
+// StreamRecord NDJSON 输出中的单行记录
+type StreamRecord struct {
+	Type  string      `json:"type"`
+	Index *int        `json:"index,omitempty"`
+	Data  interface{} `json:"data,omitempty"`
+	Error string      `json:"error,omitempty"`
+	Code  string      `json:"code,omitempty"`
+}
+
+// NDJSONOutput NDJSON 流式输出，可被多个 goroutine 并发调用
+type NDJSONOutput struct {
+	mu         sync.Mutex
+	outputFile *os.File
+	encoder    *json.Encoder
+}
+
+// NewNDJSONOutput 创建 NDJSON 输出
+func NewNDJSONOutput(outputFile string) (*NDJSONOutput, error) {
+	n := &NDJSONOutput{}
+	var w io.Writer = os.Stdout
+	if outputFile != "" {
+		f, err := os.Create(outputFile)
+		if err != nil {
+			return nil, fmt.Errorf("创建输出文件失败: %w", err)
+		}
+		n.outputFile = f
+		w = f
+	}
+	n.encoder = json.NewEncoder(w)
+	n.encoder.SetEscapeHTML(false)
+	return n, nil
+}
+
+// Print 输出数据，切片中的每个元素输出为一条结果记录并附带汇总记录
+func (n *NDJSONOutput) Print(data interface{}) error {
+	items, ok := data.([]interface{})
+	if !ok {
+		return n.writeRecord(StreamRecord{Type: RecordResult, Data: data})
+	}
+	for i, item := range items {
+		if err := n.PrintItem(i, item); err != nil {
+			return err
+		}
+	}
+	return n.PrintSummary(map[string]interface{}{"count": len(items)})
+}
+
+// PrintItem 立即输出单条结果
+func (n *NDJSONOutput) PrintItem(index int, data interface{}) error {
+	return n.writeRecord(StreamRecord{Type: RecordResult, Index: &index, Data: data})
+}
+
+// PrintSummary 输出汇总记录
+func (n *NDJSONOutput) PrintSummary(data interface{}) error {
+	return n.writeRecord(StreamRecord{Type: RecordSummary, Data: data})
+}
+
+// Error 输出错误记录
+func (n *NDJSONOutput) Error(err error) error {
+	if werr := n.writeRecord(StreamRecord{Type: RecordError, Error: err.Error(), Code: ErrorCode(err)}); werr != nil {
+		return werr
+	}
+	n.Close()
+	os.Exit(ExitCode(err))
+	return nil
+}
+
+func (n *NDJSONOutput) writeRecord(rec StreamRecord) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if err := n.encoder.Encode(rec); err != nil {
+		return fmt.Errorf("JSON 编码错误: %w", err)
+	}
+	return nil
+}
+
+// Close 关闭输出文件
+func (n *NDJSONOutput) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.outputFile != nil {
+		err := n.outputFile.Close()
+		n.outputFile = nil
+		return err
+	}
+	return nil
+}
+
 // Success 输出成功响应（JSON 格式，兼容旧代码）
 func Success(data interface{}) {
 	resp := SuccessResponse{
@@ -198,23 +408,8 @@ func Success(data interface{}) {
 
 // Error 输出错误响应（JSON 格式，兼容旧代码）
 func Error(err error) {
-	resp := ErrorResponse{
-		Success: false,
-		Error:   err.Error(),
-	}
-	printJSON(resp)
-	os.Exit(1)
-}
-
-// ErrorWithCode 输出带错误码的错误响应（JSON 格式，兼容旧代码）
-func ErrorWithCode(code, message string) {
-	resp := ErrorResponse{
-		Success: false,
-		Error:   message,
-		Code:    code,
-	}
-	printJSON(resp)
-	os.Exit(1)
+	printJSON(newErrorResponse(err))
+	os.Exit(ExitCode(err))
 }
 
 // printJSON 打印 JSON（兼容旧代码）
@@ -243,6 +438,8 @@ func GetOutput(format OutputFormat, outputFile string) (Output, error) {
 	switch format {
 	case FormatMarkdown:
 		return NewMarkdownOutput(outputFile)
+	case FormatNDJSON:
+		return NewNDJSONOutput(outputFile)
 	case FormatJSON:
 		return NewJSONOutput(true), nil
 	default:
@@ -2,7 +2,11 @@ package output
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -170,6 +174,44 @@ func TestMarkdownOutput_Print_Slice(t *testing.T) {
 	}
 }
 
+func TestMarkdownOutput_Print_SearchResults(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewMarkdownWriter(&buf)
+	data := map[string]interface{}{
+		"query": "golang",
+		"count": 2,
+		"results": []map[string]interface{}{
+			{"title": "Go", "url": "https://go.dev", "description": "The Go language", "date": "2025-02-11"},
+			{"url": "https://example.com"},
+		},
+	}
+	if err := m.Print(data); err != nil {
+		t.Fatalf("Print() failed: %v", err)
+	}
+
+	want := "# golang\n\n1. [Go](<https://go.dev>) (2025-02-11) — The Go language\n2. [https://example.com](<https://example.com>)\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+
+	// 订阅源条目显示发布时间
+	buf.Reset()
+	_ = m.Print([]interface{}{
+		map[string]interface{}{"title": "Post", "url": "https://example.com/1", "published": "2024-05-07T10:00:00Z"},
+	})
+	if want := "1. [Post](<https://example.com/1>) (2024-05-07T10:00:00Z)\n"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+
+	// 带内容的结果仍按章节输出
+	buf.Reset()
+	data["results"] = []map[string]interface{}{{"title": "Go", "url": "https://go.dev", "content": "body"}}
+	_ = m.Print(data)
+	if !contains(buf.String(), "## 1. Go") || !contains(buf.String(), "body") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+}
+
 func TestGetOutput(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -187,6 +229,11 @@ func TestGetOutput(t *testing.T) {
 			format:  FormatMarkdown,
 			wantNil: false,
 		},
+		{
+			name:    "NDJSON format",
+			format:  FormatNDJSON,
+			wantNil: false,
+		},
 		{
 			name:    "Unknown format defaults to JSON",
 			format:  "unknown",
@@ -271,3 +318,93 @@ func TestPrintError(t *testing.T) {
 func contains(s, substr string) bool {
 	return bytes.Contains([]byte(s), []byte(substr))
 }
+
+type testCodedError struct{}
+
+func (testCodedError) Error() string     { return "rate limited" }
+func (testCodedError) ErrorCode() string { return "RATE_LIMITED" }
+func (testCodedError) ExitCode() int     { return 5 }
+
+func TestErrorCodeAndExitCode(t *testing.T) {
+	plain := fmt.Errorf("plain error")
+	if code := ErrorCode(plain); code != "" {
+		t.Errorf("Expected empty code for plain error, got %q", code)
+	}
+	if exit := ExitCode(plain); exit != 1 {
+		t.Errorf("Expected exit code 1 for plain error, got %d", exit)
+	}
+
+	wrapped := fmt.Errorf("wrapped: %w", testCodedError{})
+	if code := ErrorCode(wrapped); code != "RATE_LIMITED" {
+		t.Errorf("Expected code RATE_LIMITED, got %q", code)
+	}
+	if exit := ExitCode(wrapped); exit != 5 {
+		t.Errorf("Expected exit code 5, got %d", exit)
+	}
+
+	resp := newErrorResponse(wrapped)
+	if resp.Success || resp.Code != "RATE_LIMITED" || resp.Error != "wrapped: rate limited" {
+		t.Errorf("Unexpected error response: %+v", resp)
+	}
+}
+
+func TestNDJSONOutput_Stream(t *testing.T) {
+	tmpFile := filepath.Join(t.TempDir(), "out.ndjson")
+	n, err := NewNDJSONOutput(tmpFile)
+	if err != nil {
+		t.Fatalf("NewNDJSONOutput() failed: %v", err)
+	}
+
+	if err := n.PrintItem(1, map[string]interface{}{"url": "https://b.com"}); err != nil {
+		t.Fatalf("PrintItem() failed: %v", err)
+	}
+	if err := n.PrintItem(0, map[string]interface{}{"url": "https://a.com"}); err != nil {
+		t.Fatalf("PrintItem() failed: %v", err)
+	}
+	if err := n.PrintSummary(map[string]interface{}{"total": 2}); err != nil {
+		t.Fatalf("PrintSummary() failed: %v", err)
+	}
+	n.Close()
+
+	data, err := os.ReadFile(tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %d: %q", len(lines), data)
+	}
+
+	var first StreamRecord
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("Invalid JSON line %q: %v", lines[0], err)
+	}
+	if first.Type != RecordResult || first.Index == nil || *first.Index != 1 {
+		t.Errorf("Unexpected first record: %+v", first)
+	}
+	if !contains(lines[2], `"type":"summary"`) {
+		t.Errorf("Expected summary as last line, got %s", lines[2])
+	}
+}
+
+func TestNDJSONOutput_PrintSlice(t *testing.T) {
+	tmpFile := filepath.Join(t.TempDir(), "out.ndjson")
+	n, err := NewNDJSONOutput(tmpFile)
+	if err != nil {
+		t.Fatalf("NewNDJSONOutput() failed: %v", err)
+	}
+
+	if err := n.Print([]interface{}{"a", "b"}); err != nil {
+		t.Fatalf("Print() failed: %v", err)
+	}
+	n.Close()
+
+	data, _ := os.ReadFile(tmpFile)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 2 results and a summary, got %q", data)
+	}
+	if !contains(lines[2], `"count":2`) {
+		t.Errorf("Expected count in summary, got %s", lines[2])
+	}
+}
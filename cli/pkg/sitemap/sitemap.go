@@ -0,0 +1,239 @@
+// Package sitemap 解析 sitemap 和 sitemap 索引，展开为待读取的 URL 列表。
+//
+// 支持 sitemaps.org 协议的 urlset 和 sitemapindex 两种文档，以及 gzip 压缩的文件。
+// 索引中的子 sitemap 会递归展开，同一 sitemap 只获取一次。
+package sitemap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxIndexDepth sitemap 索引的最大嵌套层数
+const maxIndexDepth = 5
+
+// maxUncompressedSize gzip 解压后的最大字节数，防止解压炸弹
+//
+// sitemaps.org 协议规定单个 sitemap 解压后不超过 50MB。
+var maxUncompressedSize int64 = 50 << 20
+
+// Entry sitemap 中的单个 URL
+type Entry struct {
+	URL     string
+	LastMod time.Time // 没有 lastmod 或无法解析时为零值
+}
+
+// Fetcher 获取 sitemap 文件内容
+type Fetcher func(ctx context.Context, url string) ([]byte, error)
+
+// Options 展开 sitemap 时的过滤条件
+type Options struct {
+	Include *regexp.Regexp // 只保留匹配的 URL，nil 表示不限制
+	Exclude *regexp.Regexp // 排除匹配的 URL，nil 表示不排除
+	Since   time.Time      // 只保留 lastmod 不早于此时间的 URL，没有 lastmod 的 URL 保留
+	Limit   int            // 最多返回的 URL 数，0 表示不限制
+
+	// OnError 在子 sitemap 获取或解析失败时调用，随后跳过该 sitemap；为 nil 时直接跳过
+	OnError func(url string, err error)
+}
+
+// document sitemap 文档，urlset 和 sitemapindex 共用
+type document struct {
+	XMLName  xml.Name
+	URLs     []location `xml:"url"`
+	Sitemaps []location `xml:"sitemap"`
+}
+
+type location struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+// Parse 解析 sitemap 文档（可以是 gzip 压缩的），返回其中的 URL 和子 sitemap
+//
+// 不是 http(s) 绝对 URL 的 loc（如 file:///etc/passwd 或相对路径）会被丢弃。
+func Parse(data []byte) ([]Entry, []string, error) {
+	data, err := decompress(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var doc document
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("解析 sitemap 失败: %w", err)
+	}
+	switch doc.XMLName.Local {
+	case "urlset", "sitemapindex":
+	default:
+		return nil, nil, fmt.Errorf("不是 sitemap 文档: <%s>", doc.XMLName.Local)
+	}
+
+	entries := make([]Entry, 0, len(doc.URLs))
+	for _, u := range doc.URLs {
+		loc := strings.TrimSpace(u.Loc)
+		if !isHTTP(loc) {
+			continue
+		}
+		lastMod, _ := ParseTime(u.LastMod)
+		entries = append(entries, Entry{URL: loc, LastMod: lastMod})
+	}
+
+	var children []string
+	for _, s := range doc.Sitemaps {
+		if loc := strings.TrimSpace(s.Loc); isHTTP(loc) {
+			children = append(children, loc)
+		}
+	}
+	return entries, children, nil
+}
+
+// isHTTP 判断 loc 是否为 http(s) 绝对 URL
+func isHTTP(loc string) bool {
+	u, err := url.Parse(loc)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// Expand 获取 sitemap 并递归展开索引，返回按文档顺序排列、去重和过滤后的 URL
+//
+// 根 sitemap 获取或解析失败时返回错误；达到 Limit 后不再获取其余的子 sitemap。
+func Expand(ctx context.Context, fetch Fetcher, sitemapURL string, opts Options) ([]Entry, error) {
+	e := &expander{
+		fetch:   fetch,
+		opts:    opts,
+		visited: make(map[string]bool),
+		seen:    make(map[string]bool),
+	}
+	if err := e.expand(ctx, sitemapURL, 0); err != nil {
+		return nil, err
+	}
+	return e.entries, nil
+}
+
+type expander struct {
+	fetch   Fetcher
+	opts    Options
+	visited map[string]bool // 已获取的 sitemap
+	seen    map[string]bool // 已收集的 URL
+	entries []Entry
+}
+
+func (e *expander) full() bool {
+	return e.opts.Limit > 0 && len(e.entries) >= e.opts.Limit
+}
+
+func (e *expander) expand(ctx context.Context, sitemapURL string, depth int) error {
+	e.visited[sitemapURL] = true
+
+	data, err := e.fetch(ctx, sitemapURL)
+	if err != nil {
+		return err
+	}
+	entries, children, err := Parse(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", sitemapURL, err)
+	}
+
+	for _, entry := range entries {
+		if e.full() {
+			return nil
+		}
+		if e.seen[entry.URL] || !e.match(entry) {
+			continue
+		}
+		e.seen[entry.URL] = true
+		e.entries = append(e.entries, entry)
+	}
+
+	for _, child := range children {
+		if e.full() || ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if e.visited[child] {
+			continue
+		}
+		if depth+1 > maxIndexDepth {
+			e.report(child, fmt.Errorf("sitemap 索引嵌套超过 %d 层", maxIndexDepth))
+			continue
+		}
+		if err := e.expand(ctx, child, depth+1); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			e.report(child, err)
+		}
+	}
+	return nil
+}
+
+func (e *expander) match(entry Entry) bool {
+	if e.opts.Include != nil && !e.opts.Include.MatchString(entry.URL) {
+		return false
+	}
+	if e.opts.Exclude != nil && e.opts.Exclude.MatchString(entry.URL) {
+		return false
+	}
+	if !e.opts.Since.IsZero() && !entry.LastMod.IsZero() && entry.LastMod.Before(e.opts.Since) {
+		return false
+	}
+	return true
+}
+
+func (e *expander) report(url string, err error) {
+	if e.opts.OnError != nil {
+		e.opts.OnError(url, err)
+	}
+}
+
+// gzipMagic gzip 文件的起始字节
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// decompress 按内容识别 gzip 压缩（服务端不一定设置 Content-Encoding）
+//
+// 解压后超过 maxUncompressedSize 时返回错误。
+func decompress(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, gzipMagic) {
+		return data, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("解压 sitemap 失败: %w", err)
+	}
+	defer r.Close()
+	out, err := io.ReadAll(io.LimitReader(r, maxUncompressedSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("解压 sitemap 失败: %w", err)
+	}
+	if int64(len(out)) > maxUncompressedSize {
+		return nil, fmt.Errorf("解压 sitemap 失败: 解压后超过 %d 字节", maxUncompressedSize)
+	}
+	return out, nil
+}
+
+// timeLayouts W3C Datetime 允许的格式
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"2006-01",
+	"2006",
+}
+
+// ParseTime 解析 sitemap lastmod 使用的 W3C Datetime，如 2024-05-01 或 2024-05-01T10:00:00+08:00
+func ParseTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("无效的时间: %q", s)
+}
@@ -0,0 +1,254 @@
+package sitemap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+const urlset = `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc> https://example.com/a </loc><lastmod>2024-01-10</lastmod></url>
+  <url><loc>https://example.com/b</loc><lastmod>2024-03-01T10:00:00+08:00</lastmod></url>
+  <url><loc>https://example.com/blog/c</loc></url>
+  <url><loc></loc></url>
+</urlset>`
+
+const index = `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>https://example.com/pages.xml</loc></sitemap>
+  <sitemap><loc>https://example.com/blog.xml.gz</loc></sitemap>
+  <sitemap><loc>https://example.com/missing.xml</loc></sitemap>
+  <sitemap><loc>https://example.com/index.xml</loc></sitemap>
+</sitemapindex>`
+
+const blog = `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>https://example.com/blog/c</loc></url>
+  <url><loc>https://example.com/blog/d</loc><lastmod>2023-12-31</lastmod></url>
+</urlset>`
+
+func gzipped(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func fakeFetcher(t *testing.T) (Fetcher, *[]string) {
+	files := map[string][]byte{
+		"https://example.com/index.xml":   []byte(index),
+		"https://example.com/pages.xml":   []byte(urlset),
+		"https://example.com/blog.xml.gz": gzipped(t, blog),
+	}
+	var fetched []string
+	return func(ctx context.Context, url string) ([]byte, error) {
+		fetched = append(fetched, url)
+		data, ok := files[url]
+		if !ok {
+			return nil, fmt.Errorf("404: %s", url)
+		}
+		return data, nil
+	}, &fetched
+}
+
+func urls(entries []Entry) string {
+	s := make([]string, len(entries))
+	for i, e := range entries {
+		s[i] = e.URL
+	}
+	return strings.Join(s, ",")
+}
+
+func TestParse(t *testing.T) {
+	entries, children, err := Parse([]byte(urlset))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := urls(entries); got != "https://example.com/a,https://example.com/b,https://example.com/blog/c" {
+		t.Errorf("urls = %s", got)
+	}
+	if len(children) != 0 {
+		t.Errorf("children = %v, want none", children)
+	}
+	if want := time.Date(2024, 3, 1, 2, 0, 0, 0, time.UTC); !entries[1].LastMod.Equal(want) {
+		t.Errorf("lastmod = %v, want %v", entries[1].LastMod, want)
+	}
+	if !entries[2].LastMod.IsZero() {
+		t.Errorf("lastmod = %v, want zero", entries[2].LastMod)
+	}
+
+	entries, children, err = Parse([]byte(index))
+	if err != nil {
+		t.Fatalf("Parse(index) error = %v", err)
+	}
+	if len(entries) != 0 || len(children) != 4 {
+		t.Errorf("Parse(index) = %d entries, %d children", len(entries), len(children))
+	}
+
+	if entries, _, err := Parse(gzipped(t, blog)); err != nil || len(entries) != 2 {
+		t.Errorf("Parse(gzip) = %d entries, %v", len(entries), err)
+	}
+
+	for _, bad := range []string{"<html><body>not found</body></html>", "not xml", ""} {
+		if _, _, err := Parse([]byte(bad)); err == nil {
+			t.Errorf("Parse(%q) expected error", bad)
+		}
+	}
+}
+
+func TestParseDropsNonHTTPLocs(t *testing.T) {
+	const doc = `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>file:///home/u/.ssh/id_rsa</loc></url>
+  <url><loc>/etc/passwd</loc></url>
+  <url><loc>./.env</loc></url>
+  <url><loc>ftp://example.com/a</loc></url>
+  <url><loc>https://example.com/ok</loc></url>
+</urlset>`
+	entries, _, err := Parse([]byte(doc))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := urls(entries); got != "https://example.com/ok" {
+		t.Errorf("urls = %s, want only https://example.com/ok", got)
+	}
+
+	const idx = `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>file:///etc/sitemap.xml</loc></sitemap>
+  <sitemap><loc>http://example.com/pages.xml</loc></sitemap>
+</sitemapindex>`
+	_, children, err := Parse([]byte(idx))
+	if err != nil {
+		t.Fatalf("Parse(index) error = %v", err)
+	}
+	if len(children) != 1 || children[0] != "http://example.com/pages.xml" {
+		t.Errorf("children = %v", children)
+	}
+}
+
+func TestParseGzipSizeLimit(t *testing.T) {
+	defer func(n int64) { maxUncompressedSize = n }(maxUncompressedSize)
+	maxUncompressedSize = int64(len(blog))
+
+	if _, _, err := Parse(gzipped(t, blog)); err != nil {
+		t.Errorf("Parse() at the limit error = %v", err)
+	}
+	if _, _, err := Parse(gzipped(t, blog+" ")); err == nil || !strings.Contains(err.Error(), "超过") {
+		t.Errorf("Parse() over the limit error = %v, want size error", err)
+	}
+}
+
+func TestExpand(t *testing.T) {
+	fetch, fetched := fakeFetcher(t)
+	var failed []string
+	entries, err := Expand(context.Background(), fetch, "https://example.com/index.xml", Options{
+		OnError: func(url string, err error) { failed = append(failed, url) },
+	})
+	if err != nil {
+		t.Fatalf("Expand() error = %v", err)
+	}
+	want := "https://example.com/a,https://example.com/b,https://example.com/blog/c,https://example.com/blog/d"
+	if got := urls(entries); got != want {
+		t.Errorf("urls = %s, want %s", got, want)
+	}
+	if strings.Join(failed, ",") != "https://example.com/missing.xml" {
+		t.Errorf("failed = %v", failed)
+	}
+	// 索引引用自身时不会重复获取
+	if len(*fetched) != 4 {
+		t.Errorf("fetched = %v", *fetched)
+	}
+
+	if _, err := Expand(context.Background(), fetch, "https://example.com/missing.xml", Options{}); err == nil {
+		t.Error("Expand() with missing root expected error")
+	}
+}
+
+func TestExpandFilters(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "include",
+			opts: Options{Include: regexp.MustCompile(`/blog/`)},
+			want: "https://example.com/blog/c,https://example.com/blog/d",
+		},
+		{
+			name: "exclude",
+			opts: Options{Exclude: regexp.MustCompile(`/blog/`)},
+			want: "https://example.com/a,https://example.com/b",
+		},
+		{
+			name: "since keeps entries without lastmod",
+			opts: Options{Since: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+			want: "https://example.com/b,https://example.com/blog/c",
+		},
+		{
+			name: "limit",
+			opts: Options{Limit: 3},
+			want: "https://example.com/a,https://example.com/b,https://example.com/blog/c",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetch, _ := fakeFetcher(t)
+			entries, err := Expand(context.Background(), fetch, "https://example.com/index.xml", tt.opts)
+			if err != nil {
+				t.Fatalf("Expand() error = %v", err)
+			}
+			if got := urls(entries); got != tt.want {
+				t.Errorf("urls = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandLimitStopsFetching(t *testing.T) {
+	fetch, fetched := fakeFetcher(t)
+	if _, err := Expand(context.Background(), fetch, "https://example.com/index.xml", Options{Limit: 1}); err != nil {
+		t.Fatalf("Expand() error = %v", err)
+	}
+	if len(*fetched) != 2 {
+		t.Errorf("fetched = %v, want index and first child only", *fetched)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2024-05-01", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024-05", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024-05-01T10:30Z", time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)},
+		{" 2024-05-01T10:30:00.5+08:00 ", time.Date(2024, 5, 1, 2, 30, 0, 5e8, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseTime(tt.in)
+		if err != nil {
+			t.Errorf("ParseTime(%q) error = %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseTime(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "yesterday", "05/01/2024"} {
+		if _, err := ParseTime(bad); err == nil {
+			t.Errorf("ParseTime(%q) expected error", bad)
+		}
+	}
+}
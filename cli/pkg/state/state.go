@@ -0,0 +1,125 @@
+// Package state 提供批量任务的断点续传状态文件。
+//
+// 状态文件为 JSONL 格式，每完成一个 URL 追加一行：
+//
+//	{"url":"https://example.com","ok":true,"result":{...},"time":"2025-01-01T00:00:00Z"}
+//
+// 同一 URL 出现多次时以最后一行为准。进程崩溃时最后一行可能不完整，
+// 加载时会忽略无法解析的行。
+package state
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxLineSize 单行记录的最大长度（页面内容可能很大）
+const maxLineSize = 64 * 1024 * 1024
+
+// Record 单个 URL 的处理记录
+type Record struct {
+	URL    string                 `json:"url"`
+	OK     bool                   `json:"ok"`
+	Result map[string]interface{} `json:"result,omitempty"`
+	Time   time.Time              `json:"time"`
+}
+
+// Store 状态文件，可并发追加
+type Store struct {
+	mu      sync.Mutex
+	file    *os.File
+	records map[string]Record
+}
+
+// Open 打开状态文件，加载已有记录，文件不存在时创建
+func Open(path string) (*Store, error) {
+	s := &Store{records: make(map[string]Record)}
+
+	if err := s.load(path); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("打开状态文件失败: %w", err)
+	}
+	s.file = f
+	return s, nil
+}
+
+// load 读取已有记录
+func (s *Store) load(path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("读取状态文件失败: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		var rec Record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil || rec.URL == "" {
+			continue
+		}
+		s.records[rec.URL] = rec
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("读取状态文件失败: %w", err)
+	}
+	return nil
+}
+
+// Lookup 查找 URL 的最新记录
+func (s *Store) Lookup(url string) (Record, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec, ok := s.records[url]
+	return rec, ok
+}
+
+// Len 返回已记录的 URL 数量
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.records)
+}
+
+// Append 追加一条记录并立即写入文件
+func (s *Store) Append(rec Record) error {
+	if rec.Time.IsZero() {
+		rec.Time = time.Now().UTC()
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("编码状态记录失败: %w", err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(line); err != nil {
+		return fmt.Errorf("写入状态文件失败: %w", err)
+	}
+	s.records[rec.URL] = rec
+	return nil
+}
+
+// Close 关闭状态文件
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
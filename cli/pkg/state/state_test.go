@@ -0,0 +1,118 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestOpen_NewFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.jsonl")
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	defer s.Close()
+
+	if s.Len() != 0 {
+		t.Errorf("Expected empty store, got %d records", s.Len())
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Expected state file to be created: %v", err)
+	}
+}
+
+func TestStore_AppendAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.jsonl")
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	records := []Record{
+		{URL: "https://a.com", OK: true, Result: map[string]interface{}{"title": "A"}},
+		{URL: "https://b.com", OK: false, Result: map[string]interface{}{"error": "boom"}},
+		// 重试成功后追加的新记录覆盖旧记录
+		{URL: "https://b.com", OK: true, Result: map[string]interface{}{"title": "B"}},
+	}
+	for _, rec := range records {
+		if err := s.Append(rec); err != nil {
+			t.Fatalf("Append() failed: %v", err)
+		}
+	}
+	s.Close()
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	defer reopened.Close()
+
+	if reopened.Len() != 2 {
+		t.Fatalf("Expected 2 records, got %d", reopened.Len())
+	}
+	rec, ok := reopened.Lookup("https://b.com")
+	if !ok || !rec.OK || rec.Result["title"] != "B" {
+		t.Errorf("Expected last record for b.com to win, got %+v", rec)
+	}
+	if rec.Time.IsZero() {
+		t.Error("Expected record time to be set")
+	}
+	if _, ok := reopened.Lookup("https://c.com"); ok {
+		t.Error("Expected c.com to be missing")
+	}
+}
+
+func TestOpen_IgnoresTruncatedLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.jsonl")
+	content := `{"url":"https://a.com","ok":true,"result":{"title":"A"}}
+{"url":"https://b.com","ok":tr`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write state file: %v", err)
+	}
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	defer s.Close()
+
+	if s.Len() != 1 {
+		t.Errorf("Expected 1 record, got %d", s.Len())
+	}
+	if _, ok := s.Lookup("https://a.com"); !ok {
+		t.Error("Expected a.com to be loaded")
+	}
+}
+
+func TestStore_ConcurrentAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.jsonl")
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			url := "https://example.com/" + string(rune('a'+i%26)) + string(rune('a'+i/26))
+			_ = s.Append(Record{URL: url, OK: true})
+		}(i)
+	}
+	wg.Wait()
+	s.Close()
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	defer reopened.Close()
+	if reopened.Len() != 50 {
+		t.Errorf("Expected 50 records, got %d", reopened.Len())
+	}
+}
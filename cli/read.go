@@ -1,12 +1,22 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/geekjourneyx/jina-cli/cli/pkg/api"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/httpheader"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/input"
 	"github.com/geekjourneyx/jina-cli/cli/pkg/output"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/sitemap"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/state"
 	"github.com/spf13/cobra"
 )
 
@@ -18,7 +28,20 @@ var ReadCmd = &cobra.Command{
 	Long:    `Read and extract content from any URL, converting it to LLM-friendly format (Markdown, HTML, or Text).`,
 	Example: `  jina read --url "https://example.com"
   jina read -u "https://x.com/user/status/123" --with-alt
-  jina read --file urls.txt --output markdown`,
+  jina read --file urls.txt --output markdown
+  jina read -u "https://example.com" --with-links-summary --with-images-summary
+  jina read -u "https://example.com" --remove-selector nav --remove-selector footer --retain-images none
+  jina read -u "https://example.com" --engine browser --locale de-DE
+  jina read -u "https://example.com" -H "X-Md-Heading-Style: setext"
+  jina read --file urls.txt --state run.jsonl --retry-failed
+  jina read --file urls.txt --output-dir ./pages
+  jina read --file pages.csv --concurrency 4
+  cat urls.txt | jina read --file -
+  jina read -u "https://example.com" --format screenshot --save-image example.png
+  jina read --sitemap "https://go.dev/sitemap.xml" --include "/doc/" --since 2024-01-01 --max-urls 50
+  jina read --input-file report.pdf
+  jina read --input-file page.html --base-url "https://example.com/docs/"
+  curl -s https://example.com | jina read --input-file -`,
 	Args: cobra.NoArgs,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		return validateReadFlags()
@@ -27,42 +50,118 @@ var ReadCmd = &cobra.Command{
 }
 
 var (
-	flagReadURL             string
-	flagReadFile            string
-	flagReadFormat          string
-	flagReadTimeout         int
-	flagReadWithAlt         bool
-	flagReadNoCache         bool
-	flagReadProxy           string
-	flagReadTargetSelector  string
-	flagReadWaitForSelector string
-	flagReadCookie          string
-	flagReadPostMethod      bool
-	flagReadOutputFile      string
+	flagReadURL            string
+	flagReadFile           string
+	flagReadFormat         string
+	flagReadTimeout        int
+	flagReadOutputFile     string
+	flagReadMaxRetries     int
+	flagReadRetryDelay     int
+	flagReadConcurrency    int
+	flagReadStateFile      string
+	flagReadRetryFailed    bool
+	flagReadOutputDir      string
+	flagReadInputFormat    string
+	flagReadCacheTolerance int
+	flagReadHeaders        []string
+	flagReadInputFile      string
+	flagReadBaseURL        string
+	flagReadSaveImage      string
+	flagReadSitemap        string
+	flagReadInclude        string
+	flagReadExclude        string
+	flagReadSince          string
+	flagReadMaxURLs        int
+	flagReadOptions        readOptions
 )
 
 func init() {
 	ReadCmd.Flags().StringVarP(&flagReadURL, "url", "u", "", "URL to read (required if --file not used)")
-	ReadCmd.Flags().StringVarP(&flagReadFile, "file", "f", "", "File containing URLs (one per line)")
-	ReadCmd.Flags().StringVarP(&flagReadFormat, "format", "F", "", "Response format: markdown, html, text, screenshot (default: markdown)")
+	ReadCmd.Flags().StringVarP(&flagReadFile, "file", "f", "", "File containing URLs or local file paths: one per line, CSV or JSONL with per-URL options (- for stdin)")
+	ReadCmd.Flags().StringVarP(&flagReadInputFile, "input-file", "i", "", "Local HTML or PDF file to upload to the Reader instead of fetching a URL (- for HTML on stdin)")
+	ReadCmd.Flags().StringVar(&flagReadBaseURL, "base-url", "", "Base URL for resolving relative links in local files")
+	ReadCmd.Flags().StringVar(&flagReadSitemap, "sitemap", "", "Read every URL listed in this sitemap or sitemap index (gzip supported)")
+	ReadCmd.Flags().StringVar(&flagReadInclude, "include", "", "With --sitemap, only read URLs matching this regular expression")
+	ReadCmd.Flags().StringVar(&flagReadExclude, "exclude", "", "With --sitemap, skip URLs matching this regular expression")
+	ReadCmd.Flags().StringVar(&flagReadSince, "since", "", "With --sitemap, only read URLs with lastmod at or after this time (2024-05-01, RFC 3339, or a duration like 72h or 7d)")
+	ReadCmd.Flags().IntVar(&flagReadMaxURLs, "max-urls", 0, "With --sitemap, read at most this many URLs")
+	ReadCmd.Flags().StringVarP(&flagReadFormat, "format", "F", "", "Response format: markdown, html, text, screenshot, pageshot (default: markdown)")
+	ReadCmd.Flags().StringVar(&flagReadSaveImage, "save-image", "", "With --format screenshot or pageshot, download the image to this path")
 	ReadCmd.Flags().IntVarP(&flagReadTimeout, "timeout", "t", 0, "Request timeout in seconds")
-	ReadCmd.Flags().BoolVar(&flagReadWithAlt, "with-alt", false, "Enable image captioning with VLM")
-	ReadCmd.Flags().BoolVar(&flagReadNoCache, "no-cache", false, "Bypass cache")
-	ReadCmd.Flags().StringVar(&flagReadProxy, "proxy", "", "Proxy server URL")
-	ReadCmd.Flags().StringVar(&flagReadTargetSelector, "target-selector", "", "CSS selector for content extraction")
-	ReadCmd.Flags().StringVar(&flagReadWaitForSelector, "wait-for-selector", "", "CSS selector to wait for")
-	ReadCmd.Flags().StringVar(&flagReadCookie, "cookie", "", "Cookie string to forward")
-	ReadCmd.Flags().BoolVar(&flagReadPostMethod, "post", false, "Use POST method (for SPA with hash routing)")
+	ReadCmd.Flags().IntVar(&flagReadOptions.serverTimeout, "server-timeout", 0, "Max seconds the Reader waits for the page to load (X-Timeout, default: config server_timeout)")
+	ReadCmd.Flags().IntVar(&flagReadCacheTolerance, "cache-tolerance", 0, "Accept cached content up to this many seconds old (X-Cache-Tolerance, default: config cache_tolerance)")
+	ReadCmd.Flags().IntVar(&flagReadOptions.tokenBudget, "token-budget", 0, "Fail requests that would use more than this many tokens (X-Token-Budget, default: config token_budget)")
+	ReadCmd.Flags().StringArrayVarP(&flagReadHeaders, "header", "H", nil, "Extra request header 'Name: value' (repeatable, added to config [headers])")
 	ReadCmd.Flags().StringVarP(&flagReadOutputFile, "output-file", "O", "", "Write output to file instead of stdout")
+	ReadCmd.Flags().IntVar(&flagReadMaxRetries, "max-retries", 0, "Max retries for transient failures (default: config max_retries)")
+	ReadCmd.Flags().IntVar(&flagReadRetryDelay, "retry-delay", 0, "Initial retry delay in milliseconds (default: config retry_delay)")
+	ReadCmd.Flags().IntVarP(&flagReadConcurrency, "concurrency", "c", 0, "Number of URLs to read in parallel with --file (default: config concurrency)")
+	ReadCmd.Flags().StringVar(&flagReadStateFile, "state", "", "Checkpoint file (JSONL) for resumable --file runs; finished URLs are skipped on rerun")
+	ReadCmd.Flags().BoolVar(&flagReadRetryFailed, "retry-failed", false, "With --state, retry URLs that failed in a previous run")
+	ReadCmd.Flags().StringVar(&flagReadInputFormat, "input-format", "auto", "Format of --file: auto, lines, csv, jsonl")
+	ReadCmd.Flags().StringVar(&flagReadOutputDir, "output-dir", "", "With --file, write each result to its own file in this directory plus a manifest.json")
+
+	addReadFlags(ReadCmd, &flagReadOptions)
 }
 
 func validateReadFlags() error {
 	// 检查 URL 来源
-	if flagReadURL == "" && flagReadFile == "" {
-		return fmt.Errorf("必须提供 --url 或 --file 参数")
+	sources := 0
+	for _, s := range []string{flagReadURL, flagReadFile, flagReadInputFile, flagReadSitemap} {
+		if s != "" {
+			sources++
+		}
+	}
+	if sources == 0 {
+		return fmt.Errorf("必须提供 --url、--file、--input-file 或 --sitemap 参数")
+	}
+	if sources > 1 {
+		return fmt.Errorf("--url、--file、--input-file 和 --sitemap 不能同时使用")
+	}
+	if flagReadSitemap == "" && (flagReadInclude != "" || flagReadExclude != "" || flagReadSince != "" || flagReadMaxURLs != 0) {
+		return fmt.Errorf("--include、--exclude、--since 和 --max-urls 只能与 --sitemap 一起使用")
+	}
+	if flagReadMaxURLs < 0 {
+		return fmt.Errorf("--max-urls 不能为负数")
+	}
+	if _, err := sitemapOptions(); err != nil {
+		return err
 	}
-	if flagReadURL != "" && flagReadFile != "" {
-		return fmt.Errorf("--url 和 --file 不能同时使用")
+	if flagReadSaveImage != "" && flagReadURL == "" {
+		return fmt.Errorf("--save-image 只能与 --url 一起使用（批量模式请使用 --output-dir）")
+	}
+	if flagReadBaseURL != "" && flagReadURL != "" {
+		return fmt.Errorf("--base-url 只能与 --input-file 或 --file 一起使用")
+	}
+	if flagReadMaxRetries < 0 || flagReadRetryDelay < 0 {
+		return fmt.Errorf("--max-retries 和 --retry-delay 不能为负数")
+	}
+	if flagReadConcurrency < 0 {
+		return fmt.Errorf("--concurrency 不能为负数")
+	}
+	for _, h := range flagReadHeaders {
+		if _, _, err := httpheader.Parse(h); err != nil {
+			return err
+		}
+	}
+	if err := flagReadOptions.validate(); err != nil {
+		return err
+	}
+	if flagReadCacheTolerance < 0 {
+		return fmt.Errorf("--cache-tolerance 不能为负数")
+	}
+	batch := flagReadFile != "" || flagReadSitemap != ""
+	if flagReadStateFile != "" && !batch {
+		return fmt.Errorf("--state 只能与 --file 或 --sitemap 一起使用")
+	}
+	if flagReadRetryFailed && flagReadStateFile == "" {
+		return fmt.Errorf("--retry-failed 需要同时指定 --state")
+	}
+	if flagReadOutputDir != "" && !batch {
+		return fmt.Errorf("--output-dir 只能与 --file 或 --sitemap 一起使用")
+	}
+	if _, err := input.ParseFormat(flagReadInputFormat); err != nil {
+		return err
 	}
 
 	return nil
@@ -72,169 +171,515 @@ func runRead(cmd *cobra.Command, args []string) {
 	// 获取输出格式
 	outputFormat := getReadOutputFormat(cmd)
 
-	// 获取 API Base URL（命令行参数优先）
-	apiBase := cfg.ReadAPIURL
-	if apiBaseFlag, _ := cmd.Parent().PersistentFlags().GetString("api-base"); apiBaseFlag != "" {
-		apiBase = apiBaseFlag
-	}
-
-	// 获取 API Key（命令行参数优先）
-	apiKey := cfg.APIKey
-	if apiKeyFlag, _ := cmd.Parent().PersistentFlags().GetString("api-key"); apiKeyFlag != "" {
-		apiKey = apiKeyFlag
-	}
-
-	// 获取超时时间
-	timeout := cfg.Timeout
-	if flagReadTimeout > 0 {
-		timeout = flagReadTimeout
-	}
-
 	// 获取响应格式
-	responseFormat := cfg.DefaultResponseFormat
-	if flagReadFormat != "" {
-		responseFormat = flagReadFormat
+	responseFormat := responseFormatOf(flagReadFormat)
+	if flagReadSaveImage != "" && !slices.Contains(api.ScreenshotFormats, responseFormat) {
+		output.Error(api.NewError(api.CodeInvalidInput, fmt.Errorf("--save-image 需要 --format screenshot 或 pageshot")))
 	}
 
-	// 创建 API 客户端
-	client := api.NewClient(apiBase, cfg.SearchAPIURL, apiKey, timeout)
+	// 创建 API 客户端（缓存有效期取决于 --cache-tolerance，需先应用）
+	applyCacheTolerance(cmd, flagReadCacheTolerance)
+	client := newReadClient(cmd, flagReadTimeout, flagReadMaxRetries, flagReadRetryDelay, flagReadHeaders, &flagReadOptions)
 
 	// 获取输出处理器
 	out, err := output.GetOutput(output.OutputFormat(outputFormat), flagReadOutputFile)
 	if err != nil {
-		output.Error(err)
+		output.Error(api.NewError(api.CodeInternal, err))
 	}
-	defer func() {
-		if closer, ok := out.(interface{ Close() error }); ok {
-			closer.Close()
-		}
-	}()
+	defer closeOutput(out)
+
+	ctx := cmd.Context()
 
 	// 处理 URL
-	if flagReadURL != "" {
+	switch {
+	case flagReadURL != "":
 		// 单个 URL
-		processURL(client, flagReadURL, responseFormat, out)
-	} else {
+		processURL(ctx, client, flagReadURL, responseFormat, out)
+	case flagReadInputFile != "":
+		// 本地文件
+		processLocalFile(ctx, client, flagReadInputFile, responseFormat, out)
+	case flagReadSitemap != "":
+		// sitemap 中的 URL
+		items, err := loadSitemapItems(ctx, client)
+		if err != nil {
+			_ = out.Error(err)
+			return
+		}
+		processBatch(ctx, client, items, responseFormat, outputFormat == "markdown", out)
+	default:
 		// 批量处理
-		processBatch(client, flagReadFile, responseFormat, out)
+		items, err := loadBatchFile(flagReadFile)
+		if err != nil {
+			_ = out.Error(err)
+			return
+		}
+		processBatch(ctx, client, items, responseFormat, outputFormat == "markdown", out)
+	}
+}
+
+// applyInputItem 用输入行中的选项覆盖命令行参数
+func applyInputItem(req *api.ReadRequest, item input.Item) {
+	if item.Format != "" {
+		req.ResponseFormat = item.Format
+	}
+	if item.TargetSelector != "" {
+		req.TargetSelector = item.TargetSelector
+	}
+	if item.WaitForSelector != "" {
+		req.WaitForSelector = item.WaitForSelector
+	}
+	if item.Cookie != "" {
+		req.Cookie = item.Cookie
+	}
+	if item.NoCache != nil {
+		req.NoCache = *item.NoCache
+	}
+	if item.Post != nil {
+		req.PostMethod = *item.Post
+	}
+	if len(item.Headers) > 0 {
+		headers := make(map[string]string, len(req.Headers)+len(item.Headers))
+		for k, v := range req.Headers {
+			headers[k] = v
+		}
+		for k, v := range item.Headers {
+			headers[k] = v
+		}
+		req.Headers = headers
 	}
 }
 
-func processURL(client *api.Client, url, responseFormat string, out output.Output) {
-	req := &api.ReadRequest{
-		URL:              url,
-		Method:           "GET",
-		ResponseFormat:   responseFormat,
-		WithGeneratedAlt: flagReadWithAlt,
-		NoCache:          flagReadNoCache,
-		ProxyURL:         flagReadProxy,
-		TargetSelector:   flagReadTargetSelector,
-		WaitForSelector:  flagReadWaitForSelector,
-		Cookie:           flagReadCookie,
-		PostMethod:       flagReadPostMethod,
-	}
-
-	resp, err := client.Read(req)
+// processLocalFile 将本地 HTML 或 PDF 文件提交给 Reader，输出与读取 URL 相同的结构化结果
+func processLocalFile(ctx context.Context, client *api.Client, path, responseFormat string, out output.Output) {
+	doc, err := input.LoadDocument(path, os.Stdin)
+	if err != nil {
+		_ = out.Error(api.NewError(api.CodeInvalidInput, err))
+		return
+	}
+
+	req := buildReadRequest(flagReadBaseURL, responseFormat, &flagReadOptions)
+	applyDocument(req, doc)
+	resp, err := client.ReadContext(ctx, req)
 	if err != nil {
-		// out.Error 会调用 os.Exit，这里只是满足 lint 检查
 		_ = out.Error(err)
 		return
 	}
 
-	// 构建输出数据
-	result := map[string]interface{}{
-		"url":     resp.URL,
-		"content": resp.Content,
+	out.Print(documentResult(readResponseToMap(resp, responseFormat), doc.Path))
+}
+
+// applyDocument 将本地文档内容放入 Read 请求，请求 URL 作为基础 URL
+func applyDocument(req *api.ReadRequest, doc *input.Document) {
+	if doc.Kind == input.DocumentPDF {
+		req.PDF = doc.Data
+	} else {
+		req.HTML = string(doc.Data)
 	}
+}
+
+// documentResult 在本地文件的结果中记录文件路径，未指定基础 URL 时不输出 url 字段
+func documentResult(result map[string]interface{}, path string) map[string]interface{} {
+	if url, _ := result["url"].(string); url == "" {
+		delete(result, "url")
+	}
+	result["file"] = path
+	return result
+}
 
-	// 尝试从内容中提取标题（对于 Markdown 格式）
-	if responseFormat == "markdown" || responseFormat == "" {
-		if title := extractTitle(resp.Content); title != "" {
-			result["title"] = title
+// readBatchLocalFile 读取批量任务中的本地文件，失败时返回带 error 字段的结果，被中断时返回 nil
+func readBatchLocalFile(ctx context.Context, client *api.Client, req *api.ReadRequest, path string) map[string]interface{} {
+	doc, err := input.LoadDocument(path, os.Stdin)
+	if err != nil {
+		return map[string]interface{}{
+			"file":  path,
+			"error": err.Error(),
+			"code":  api.CodeInvalidInput,
 		}
 	}
+	req.URL = flagReadBaseURL
+	applyDocument(req, doc)
 
-	out.Print(result)
+	result := readBatchURL(ctx, client, req)
+	if result == nil {
+		return nil
+	}
+	return documentResult(result, path)
 }
 
-func processBatch(client *api.Client, filename, responseFormat string, out output.Output) {
-	// 读取文件
-	content, err := os.ReadFile(filename)
+func processURL(ctx context.Context, client *api.Client, url, responseFormat string, out output.Output) {
+	req := buildReadRequest(url, responseFormat, &flagReadOptions)
+
+	resp, err := client.ReadContext(ctx, req)
 	if err != nil {
-		_ = out.Error(fmt.Errorf("读取文件失败: %w", err))
+		// out.Error 会调用 os.Exit，这里只是满足 lint 检查
+		_ = out.Error(err)
 		return
 	}
 
-	// 解析 URL 列表
-	urls := parseURLList(string(content))
-	if len(urls) == 0 {
-		_ = out.Error(fmt.Errorf("文件中没有找到有效的 URL"))
-		return
+	result := readResponseToMap(resp, responseFormat)
+	if flagReadSaveImage != "" {
+		image, err := saveScreenshot(ctx, client, resp.Content, flagReadSaveImage)
+		if err != nil {
+			_ = out.Error(err)
+			return
+		}
+		result["image"] = image
 	}
+	out.Print(result)
+}
 
-	// 获取输出格式用于显示进度
-	outputFormat := getReadOutputFormat(nil)
-	if outputFormat == "markdown" {
-		fmt.Fprintf(os.Stderr, "正在处理 %d 个 URL...\n", len(urls))
+// saveScreenshot 下载截图并写入 path，返回实际的本地路径、尺寸和字节数
+//
+// path 的扩展名与截图的实际格式不符时（如 --save-image out.png 下载到 JPEG），
+// 改用实际格式的扩展名并提示，避免文件内容与扩展名不一致。
+func saveScreenshot(ctx context.Context, client *api.Client, imageURL, path string) (map[string]interface{}, error) {
+	shot, err := client.DownloadScreenshotContext(ctx, imageURL)
+	if err != nil {
+		return nil, err
+	}
+	if fixed := screenshotPath(path, shot); fixed != path {
+		fmt.Fprintf(os.Stderr, "警告: 截图格式为 %s，已保存为 %s\n", shot.ContentType, fixed)
+		path = fixed
+	}
+	if err := os.WriteFile(path, shot.Data, 0644); err != nil {
+		return nil, api.NewError(api.CodeInternal, fmt.Errorf("写入图片失败: %w", err))
 	}
 
-	// 处理每个 URL
-	results := make([]map[string]interface{}, 0, len(urls))
-	for i, url := range urls {
-		if outputFormat == "markdown" {
-			fmt.Fprintf(os.Stderr, "正在处理 [%d/%d]: %s\n", i+1, len(urls), url)
+	image := map[string]interface{}{
+		"path":         path,
+		"bytes":        len(shot.Data),
+		"content_type": shot.ContentType,
+	}
+	if shot.Width > 0 && shot.Height > 0 {
+		image["width"] = shot.Width
+		image["height"] = shot.Height
+	}
+	return image, nil
+}
+
+// screenshotPath 返回与截图格式一致的保存路径，扩展名不符时替换为实际格式的扩展名
+func screenshotPath(path string, shot *api.Screenshot) string {
+	want := shot.Extension()
+	ext := filepath.Ext(path)
+	switch strings.ToLower(ext) {
+	case want:
+		return path
+	case ".jpeg":
+		if want == ".jpg" {
+			return path
+		}
+	}
+	return strings.TrimSuffix(path, ext) + want
+}
+
+// loadBatchFile 读取并解析 --file 指定的输入列表（- 表示标准输入）
+func loadBatchFile(filename string) ([]input.Item, error) {
+	var content []byte
+	var err error
+	if filename == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(filename)
+	}
+	if err != nil {
+		return nil, api.NewError(api.CodeInvalidInput, fmt.Errorf("读取文件失败: %w", err))
+	}
+
+	format, _ := input.ParseFormat(flagReadInputFormat)
+	items, err := input.Parse(content, format, filename)
+	if err != nil {
+		return nil, api.NewError(api.CodeInvalidInput, err)
+	}
+	if len(items) == 0 {
+		return nil, api.NewError(api.CodeInvalidInput, fmt.Errorf("文件中没有找到有效的 URL"))
+	}
+	for i := range items {
+		items[i].AllowLocal = true
+	}
+	return items, nil
+}
+
+// sitemapOptions 根据 --include、--exclude、--since 和 --max-urls 构建 sitemap 过滤条件
+func sitemapOptions() (sitemap.Options, error) {
+	opts := sitemap.Options{Limit: flagReadMaxURLs}
+	var err error
+	if flagReadInclude != "" {
+		if opts.Include, err = regexp.Compile(flagReadInclude); err != nil {
+			return opts, fmt.Errorf("无效的 --include: %w", err)
+		}
+	}
+	if flagReadExclude != "" {
+		if opts.Exclude, err = regexp.Compile(flagReadExclude); err != nil {
+			return opts, fmt.Errorf("无效的 --exclude: %w", err)
 		}
+	}
+	if flagReadSince != "" {
+		if opts.Since, err = parseSince(flagReadSince); err != nil {
+			return opts, fmt.Errorf("无效的 --since: %w", err)
+		}
+	}
+	return opts, nil
+}
+
+// loadSitemapItems 获取 --sitemap 并展开为输入列表，lastmod 作为 meta 字段回显
+//
+// 子 sitemap 获取失败时只输出警告，不影响其他 sitemap。
+func loadSitemapItems(ctx context.Context, client *api.Client) ([]input.Item, error) {
+	opts, err := sitemapOptions()
+	if err != nil {
+		return nil, api.NewError(api.CodeInvalidInput, err)
+	}
+	opts.OnError = func(url string, err error) {
+		fmt.Fprintf(os.Stderr, "警告: 跳过 sitemap %s: %v\n", url, err)
+	}
 
-		req := &api.ReadRequest{
-			URL:              url,
-			Method:           "GET",
-			ResponseFormat:   responseFormat,
-			WithGeneratedAlt: flagReadWithAlt,
-			NoCache:          flagReadNoCache,
-			ProxyURL:         flagReadProxy,
+	entries, err := sitemap.Expand(ctx, client.DownloadContext, flagReadSitemap, opts)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, api.NewError(api.CodeInterrupted, err)
 		}
+		return nil, api.NewError(api.CodeInvalidInput, err)
+	}
+	if len(entries) == 0 {
+		return nil, api.NewError(api.CodeInvalidInput, fmt.Errorf("sitemap 中没有符合条件的 URL"))
+	}
+
+	items := make([]input.Item, len(entries))
+	for i, entry := range entries {
+		items[i] = input.Item{URL: entry.URL}
+		if !entry.LastMod.IsZero() {
+			items[i].Meta = map[string]interface{}{"lastmod": entry.LastMod.Format(time.RFC3339)}
+		}
+	}
+	return items, nil
+}
+
+// processBatch 批量处理 URL，被中断时输出已完成的结果并以中断退出码退出
+//
+// URL 由 worker pool 并发读取，输出顺序与输入顺序一致；NDJSON 输出时按完成顺序逐条写出。
+// 指定 --state 时，每完成一个 URL 立即写入状态文件；重新运行时跳过已成功的 URL，
+// 失败的 URL 仅在 --retry-failed 时重试，最终与本次结果合并输出。
+// 指定 --output-dir 时，每个结果写入单独的文件，输出中只保留文件信息。
+// CSV/JSONL 输入的每一行可以覆盖读取选项，其余列作为 meta 字段回显。
+func processBatch(ctx context.Context, client *api.Client, items []input.Item, responseFormat string, showProgress bool, out output.Output) {
+	var err error
+	urls := make([]string, len(items))
+	for i, item := range items {
+		urls[i] = item.URL
+	}
+
+	concurrency := batchConcurrency(flagReadConcurrency)
+
+	// 从状态文件恢复已完成的结果
+	var store *state.Store
+	if flagReadStateFile != "" {
+		store, err = state.Open(flagReadStateFile)
+		if err != nil {
+			_ = out.Error(api.NewError(api.CodeInternal, err))
+			return
+		}
+		defer store.Close()
+	}
 
-		resp, err := client.Read(req)
+	// 每个结果写入输出目录
+	var dir *output.DirWriter
+	if flagReadOutputDir != "" {
+		dir, err = output.NewDirWriter(flagReadOutputDir)
 		if err != nil {
-			result := map[string]interface{}{
-				"url":   url,
-				"error": err.Error(),
+			_ = out.Error(api.NewError(api.CodeInternal, err))
+			return
+		}
+	}
+
+	collector := newBatchCollector(out, len(urls), showProgress)
+	pending := make([]int, 0, len(urls))
+	for i, url := range urls {
+		if store != nil {
+			if rec, ok := store.Lookup(url); ok && (rec.OK || !flagReadRetryFailed) {
+				collector.add(i, "", rec.Result)
+				continue
 			}
-			results = append(results, result)
-			continue
 		}
+		pending = append(pending, i)
+	}
 
-		result := map[string]interface{}{
-			"url":     resp.URL,
-			"content": resp.Content,
+	if showProgress {
+		fmt.Fprintf(os.Stderr, "正在处理 %d 个 URL（并发数 %d）...\n", len(urls), concurrency)
+		if restored := len(urls) - len(pending); restored > 0 {
+			fmt.Fprintf(os.Stderr, "从状态文件恢复 %d 个结果，剩余 %d 个\n", restored, len(pending))
 		}
+	}
 
-		// 尝试提取标题
-		if title := extractTitle(resp.Content); title != "" {
-			result["title"] = title
+	// 并发处理剩余 URL
+	forEachConcurrent(ctx, len(pending), concurrency, func(j int) {
+		i := pending[j]
+		req := buildReadRequest(urls[i], responseFormat, &flagReadOptions)
+		applyInputItem(req, items[i])
+		var result map[string]interface{}
+		// 只有 --file 的项可以读取本地文件，sitemap 中的 file:// 等 loc 不能读取本机文件
+		if path, ok := input.LocalPath(urls[i]); ok && items[i].AllowLocal {
+			result = readBatchLocalFile(ctx, client, req, path)
+		} else {
+			result = readBatchURL(ctx, client, req)
 		}
+		if result == nil {
+			return
+		}
+		if dir != nil {
+			result = saveResultToDir(ctx, client, dir, urls[i], result, req.ResponseFormat)
+			if result == nil {
+				return
+			}
+		}
+		if len(items[i].Meta) > 0 {
+			result["meta"] = items[i].Meta
+		}
+		if store != nil {
+			_, failed := result["error"]
+			if err := store.Append(state.Record{URL: urls[i], OK: !failed, Result: result}); err != nil {
+				fmt.Fprintf(os.Stderr, "警告: %v\n", err)
+			}
+		}
+		collector.add(i, urls[i], result)
+	})
+
+	interrupted := ctx.Err() != nil
+	if interrupted && store != nil {
+		store.Close()
+	}
+	// 中断时 finish 会直接退出，因此先写入清单
+	if dir != nil {
+		if err := dir.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "警告: %v\n", err)
+		}
+	}
+	collector.finish(interrupted)
+}
 
-		results = append(results, result)
+// readBatchURL 读取批量任务中的单个 URL，失败时返回带 error 字段的结果，被中断时返回 nil
+func readBatchURL(ctx context.Context, client *api.Client, req *api.ReadRequest) map[string]interface{} {
+	resp, err := client.ReadContext(ctx, req)
+	if err != nil {
+		// 被中断的请求不计入结果
+		if ctx.Err() != nil {
+			return nil
+		}
+		return map[string]interface{}{
+			"url":   req.URL,
+			"error": err.Error(),
+			"code":  api.ErrorCodeOf(err),
+		}
 	}
 
-	// 输出结果
-	out.Print(results)
+	return readResponseToMap(resp, req.ResponseFormat)
 }
 
-func parseURLList(content string) []string {
-	lines := strings.Split(content, "\n")
-	urls := make([]string, 0, len(lines))
+// saveResultToDir 将单个结果写入输出目录，返回写入输出的文件信息，被中断时返回 nil
+//
+// markdown 格式按 Markdown 输出格式写入；screenshot/pageshot 格式下载截图后写入图片；
+// 其他格式写入原始内容。失败的结果只记录到清单中。
+func saveResultToDir(ctx context.Context, client *api.Client, dir *output.DirWriter, url string, result map[string]interface{}, responseFormat string) map[string]interface{} {
+	if errMsg, failed := result["error"]; failed {
+		code, _ := result["code"].(string)
+		dir.Record(url, output.ManifestEntry{
+			Status: output.StatusError,
+			Error:  fmt.Sprint(errMsg),
+			Code:   code,
+		})
+		return result
+	}
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		// 跳过空行和注释行
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
+	title, _ := result["title"].(string)
+	content, _ := result["content"].(string)
+
+	var entry output.ManifestEntry
+	var err error
+	switch ext := output.ExtensionForFormat(responseFormat); ext {
+	case ".md":
+		entry, err = dir.SaveMarkdown(url, title, result)
+	case ".png":
+		var shot *api.Screenshot
+		shot, err = client.DownloadScreenshotContext(ctx, content)
+		if err != nil && ctx.Err() != nil {
+			return nil
+		}
+		if err == nil {
+			entry, err = dir.SaveImage(url, title, shot.Extension(), shot.Data, shot.Width, shot.Height)
+		}
+	default:
+		entry, err = dir.Save(url, title, ext, func(w io.Writer) error {
+			_, werr := io.WriteString(w, content)
+			return werr
+		})
+	}
+
+	if err != nil {
+		failed := map[string]interface{}{
+			"url":   url,
+			"error": err.Error(),
+			"code":  api.ErrorCodeOf(err),
 		}
-		urls = append(urls, line)
+		dir.Record(url, output.ManifestEntry{
+			Status: output.StatusError,
+			Title:  title,
+			Error:  err.Error(),
+			Code:   api.ErrorCodeOf(err),
+		})
+		return failed
+	}
+
+	saved := map[string]interface{}{
+		"url":   url,
+		"file":  filepath.Join(dir.Dir(), entry.File),
+		"bytes": entry.Bytes,
+	}
+	if title != "" {
+		saved["title"] = title
+	}
+	if entry.Width > 0 && entry.Height > 0 {
+		saved["width"] = entry.Width
+		saved["height"] = entry.Height
+	}
+	return saved
+}
+
+// readResponseToMap 将 Read 响应转换为输出数据，空字段不输出
+func readResponseToMap(resp *api.ReadResponse, responseFormat string) map[string]interface{} {
+	result := map[string]interface{}{
+		"url":     resp.URL,
+		"content": resp.Content,
 	}
 
-	return urls
+	title := resp.Title
+	// 服务端未返回标题时，尝试从 Markdown 内容中提取
+	if title == "" && (responseFormat == "markdown" || responseFormat == "") {
+		title = extractTitle(resp.Content)
+	}
+	if title != "" {
+		result["title"] = title
+	}
+	if resp.FinalURL != "" && resp.FinalURL != resp.URL {
+		result["final_url"] = resp.FinalURL
+	}
+	if resp.Description != "" {
+		result["description"] = resp.Description
+	}
+	if resp.PublishedTime != "" {
+		result["published_time"] = resp.PublishedTime
+	}
+	if len(resp.Images) > 0 {
+		result["images"] = resp.Images
+	}
+	if len(resp.Links) > 0 {
+		result["links"] = resp.Links
+	}
+	if resp.Warning != "" {
+		result["warning"] = resp.Warning
+	}
+	if resp.Usage.Tokens > 0 {
+		result["usage"] = map[string]interface{}{"tokens": resp.Usage.Tokens}
+	}
+	return result
 }
 
 func extractTitle(content string) string {
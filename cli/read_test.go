@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/geekjourneyx/jina-cli/cli/pkg/api"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/config"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/input"
+	"github.com/spf13/cobra"
+)
+
+// fakeReader 模拟 Read API：URL 中包含 fail 的页面在 fixed 之前返回 422
+type fakeReader struct {
+	fixed atomic.Bool
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func (f *fakeReader) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	target := strings.TrimPrefix(r.URL.Path, "/")
+	f.mu.Lock()
+	f.requests = append(f.requests, target)
+	f.mu.Unlock()
+
+	if strings.Contains(target, "fail") && !f.fixed.Load() {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"code":422,"name":"AssertionFailureError","message":"Failed to goto"}`))
+		return
+	}
+	_, _ = w.Write([]byte(`{"code":200,"data":{"title":"Page","url":"` + target + `","content":"body of ` + target + `","usage":{"tokens":10}}}`))
+}
+
+// takeRequests 返回并清空已收到的请求
+func (f *fakeReader) takeRequests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	requests := f.requests
+	f.requests = nil
+	return requests
+}
+
+func TestProcessBatch_StateResume(t *testing.T) {
+	reader := &fakeReader{}
+	server := httptest.NewServer(reader)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, server.URL, "", 5)
+	client.SetRetryPolicy(api.RetryPolicy{})
+
+	oldCfg, oldState, oldRetry := cfg, flagReadStateFile, flagReadRetryFailed
+	t.Cleanup(func() { cfg, flagReadStateFile, flagReadRetryFailed = oldCfg, oldState, oldRetry })
+	cfg = &config.Config{Concurrency: 2}
+	flagReadStateFile = filepath.Join(t.TempDir(), "run.jsonl")
+
+	items := []input.Item{
+		{URL: "https://example.com/a"},
+		{URL: "https://example.com/fail"},
+		{URL: "https://example.com/b"},
+	}
+
+	tests := []struct {
+		name         string
+		retryFailed  bool
+		fixed        bool
+		wantRequests []string
+		wantFailed   []bool
+	}{
+		{"first run", false, false, []string{"https://example.com/a", "https://example.com/fail", "https://example.com/b"}, []bool{false, true, false}},
+		{"resume skips finished and failed", false, true, nil, []bool{false, true, false}},
+		{"retry failed merges with saved results", true, true, []string{"https://example.com/fail"}, []bool{false, false, false}},
+		{"all finished", true, true, nil, []bool{false, false, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flagReadRetryFailed = tt.retryFailed
+			reader.fixed.Store(tt.fixed)
+
+			out := &recordOutput{}
+			processBatch(context.Background(), client, items, "markdown", false, out)
+
+			requests := reader.takeRequests()
+			if len(requests) != len(tt.wantRequests) {
+				t.Fatalf("requests = %v, want %v", requests, tt.wantRequests)
+			}
+			for _, want := range tt.wantRequests {
+				if !slices.Contains(requests, want) {
+					t.Errorf("requests = %v, missing %s", requests, want)
+				}
+			}
+
+			// 恢复的结果和本次结果按输入顺序合并输出
+			if len(out.printed) != 1 {
+				t.Fatalf("Print called %d times, want 1", len(out.printed))
+			}
+			data := out.printed[0].([]interface{})
+			if len(data) != len(items) {
+				t.Fatalf("printed %d results, want %d", len(data), len(items))
+			}
+			for i, item := range data {
+				result := item.(map[string]interface{})
+				if result["url"] != items[i].URL {
+					t.Errorf("result %d url = %v, want %s", i, result["url"], items[i].URL)
+				}
+				if _, failed := result["error"]; failed != tt.wantFailed[i] {
+					t.Errorf("result %d failed = %v, want %v (%v)", i, failed, tt.wantFailed[i], result)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessBatch_LocalPathOnlyFromFile(t *testing.T) {
+	reader := &fakeReader{}
+	server := httptest.NewServer(reader)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, server.URL, "", 5)
+	client.SetRetryPolicy(api.RetryPolicy{})
+
+	oldCfg, oldState := cfg, flagReadStateFile
+	t.Cleanup(func() { cfg, flagReadStateFile = oldCfg, oldState })
+	cfg = &config.Config{Concurrency: 1}
+	flagReadStateFile = ""
+
+	secret := filepath.Join(t.TempDir(), "secret.txt")
+	if err := os.WriteFile(secret, []byte("TOP SECRET"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// 来自 sitemap 的项（AllowLocal 为 false）不能读取本地文件
+	out := &recordOutput{}
+	processBatch(context.Background(), client, []input.Item{{URL: "file://" + secret}}, "markdown", false, out)
+	result := out.printed[0].([]interface{})[0].(map[string]interface{})
+	if _, ok := result["file"]; ok {
+		t.Fatalf("sitemap item read local file: %v", result)
+	}
+	if strings.Contains(fmt.Sprint(result), "TOP SECRET") {
+		t.Fatalf("local file content leaked: %v", result)
+	}
+	reader.takeRequests()
+
+	// 来自 --file 的项可以读取本地文件
+	out = &recordOutput{}
+	processBatch(context.Background(), client, []input.Item{{URL: "file://" + secret, AllowLocal: true}}, "markdown", false, out)
+	result = out.printed[0].([]interface{})[0].(map[string]interface{})
+	if _, failed := result["error"]; failed {
+		t.Fatalf("local file read failed: %v", result)
+	}
+}
+
+func TestReadOptionsApplyConfigDefaults(t *testing.T) {
+	oldCfg := cfg
+	t.Cleanup(func() { cfg = oldCfg })
+	cfg = &config.Config{WithIframe: true, WithShadowDOM: true}
+
+	tests := []struct {
+		name          string
+		args          []string
+		wantIframe    bool
+		wantShadowDOM bool
+	}{
+		{"config defaults", nil, true, true},
+		{"explicit false beats config", []string{"--with-iframe=false", "--with-shadow-dom=false"}, false, false},
+		{"explicit true", []string{"--with-iframe"}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts readOptions
+			cmd := &cobra.Command{Use: "read"}
+			addReadFlags(cmd, &opts)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			opts.applyConfigDefaults(cmd)
+
+			req := buildReadRequest("https://example.com", "markdown", &opts)
+			if req.WithIframe != tt.wantIframe || req.WithShadowDOM != tt.wantShadowDOM {
+				t.Errorf("WithIframe = %t, WithShadowDOM = %t; want %t, %t", req.WithIframe, req.WithShadowDOM, tt.wantIframe, tt.wantShadowDOM)
+			}
+		})
+	}
+}
+
+func TestScreenshotPath(t *testing.T) {
+	tests := []struct {
+		path        string
+		contentType string
+		want        string
+	}{
+		{"out.png", "image/png", "out.png"},
+		{"out.png", "image/jpeg", "out.jpg"},
+		{"out.PNG", "image/webp", "out.webp"},
+		{"shots/out.jpeg", "image/jpeg", "shots/out.jpeg"},
+		{"shots/out", "image/png", "shots/out.png"},
+		{"out.jpg", "application/octet-stream", "out.png"},
+	}
+	for _, tt := range tests {
+		got := screenshotPath(tt.path, &api.Screenshot{ContentType: tt.contentType})
+		if got != tt.want {
+			t.Errorf("screenshotPath(%q, %s) = %q, want %q", tt.path, tt.contentType, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/geekjourneyx/jina-cli/cli/pkg/api"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/config"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// readOptions 读取页面的选项，read、search --read-results、crawl 和 feed --read 共用
+//
+// 每个命令持有自己的一份，通过 addReadFlags 注册同一组参数；
+// 未指定的选项取配置文件的默认值：开关参数在 applyConfigDefaults 中处理，其余在 buildReadRequest 中处理。
+type readOptions struct {
+	targetSelector  string
+	waitForSelector string
+	removeSelectors []string
+	retainImages    string
+	withIframe      bool
+	withShadowDOM   bool
+	withAlt         bool
+	linksSummary    bool
+	imagesSummary   bool
+	noCache         bool
+	proxy           string
+	cookie          string
+	engine          string
+	locale          string
+	userAgent       string
+	referer         string
+	post            bool
+
+	serverTimeout int               // --server-timeout，read 命令注册，search 在运行时复制自己的参数
+	tokenBudget   int               // --token-budget，read 命令注册，search 在运行时复制自己的参数
+	headers       map[string]string // 合并后的请求头，在命令运行时设置
+}
+
+// addReadFlags 注册读取页面的参数
+func addReadFlags(cmd *cobra.Command, opts *readOptions) {
+	flags := cmd.Flags()
+	flags.StringVar(&opts.targetSelector, "target-selector", "", "CSS selector for content extraction")
+	flags.StringVar(&opts.waitForSelector, "wait-for-selector", "", "CSS selector to wait for")
+	flags.StringArrayVar(&opts.removeSelectors, "remove-selector", nil, "CSS selector of elements to remove, e.g. nav or .cookie-banner (repeatable, default: config remove_selector)")
+	flags.StringVar(&opts.retainImages, "retain-images", "", "Image handling: none, all, alt (default: config retain_images)")
+	flags.BoolVar(&opts.withIframe, "with-iframe", false, "Include content from iframes")
+	flags.BoolVar(&opts.withShadowDOM, "with-shadow-dom", false, "Include content from shadow DOM")
+	flags.BoolVar(&opts.withAlt, "with-alt", false, "Enable image captioning with VLM")
+	flags.BoolVar(&opts.linksSummary, "with-links-summary", false, "Return all links on the page as a structured links list")
+	flags.BoolVar(&opts.imagesSummary, "with-images-summary", false, "Return all images on the page as a structured images list")
+	flags.BoolVar(&opts.noCache, "no-cache", false, "Bypass cache")
+	flags.StringVar(&opts.proxy, "proxy", "", "Proxy server URL")
+	flags.StringVar(&opts.cookie, "cookie", "", "Cookie string to forward")
+	flags.StringVar(&opts.engine, "engine", "", "Fetch engine: browser, direct, cf-browser-rendering")
+	flags.StringVar(&opts.locale, "locale", "", "Browser locale used to render the page, e.g. en-US")
+	flags.StringVar(&opts.userAgent, "user-agent", "", "User-Agent used to fetch the target page")
+	flags.StringVar(&opts.referer, "referer", "", "Referer used to fetch the target page")
+	flags.BoolVar(&opts.post, "post", false, "Use POST method (for SPA with hash routing)")
+}
+
+// validate 校验读取选项
+func (o *readOptions) validate() error {
+	if o.engine != "" && !slices.Contains(api.Engines, o.engine) {
+		return fmt.Errorf("无效的 --engine: %s（可选 browser、direct、cf-browser-rendering）", o.engine)
+	}
+	if o.retainImages != "" && !slices.Contains(config.RetainImagesModes, o.retainImages) {
+		return fmt.Errorf("无效的 --retain-images: %s（可选 none、all、alt）", o.retainImages)
+	}
+	if o.serverTimeout < 0 || o.tokenBudget < 0 {
+		return fmt.Errorf("--server-timeout 和 --token-budget 不能为负数")
+	}
+	return nil
+}
+
+// applyConfigDefaults 命令行未指定的 --with-iframe 和 --with-shadow-dom 取配置文件的值
+//
+// 与 configureClient 相同用 Changed 判断，显式的 --with-iframe=false 可以关闭配置中的默认值。
+func (o *readOptions) applyConfigDefaults(cmd *cobra.Command) {
+	if !cmd.Flags().Changed("with-iframe") {
+		o.withIframe = cfg.WithIframe
+	}
+	if !cmd.Flags().Changed("with-shadow-dom") {
+		o.withShadowDOM = cfg.WithShadowDOM
+	}
+}
+
+// newReadClient 创建读取页面的 API 客户端，合并后的请求头写入 opts.headers
+//
+// --api-base 和 --api-key 优先于配置文件；timeout、maxRetries、retryDelay 和 headerFlags
+// 为命令的 --timeout、--max-retries、--retry-delay 和 --header 参数，未指定时使用配置。
+func newReadClient(cmd *cobra.Command, timeout, maxRetries, retryDelay int, headerFlags []string, opts *readOptions) *api.Client {
+	apiBase := cfg.ReadAPIURL
+	if apiBaseFlag, _ := cmd.Parent().PersistentFlags().GetString("api-base"); apiBaseFlag != "" {
+		apiBase = apiBaseFlag
+	}
+	apiKey := cfg.APIKey
+	if apiKeyFlag, _ := cmd.Parent().PersistentFlags().GetString("api-key"); apiKeyFlag != "" {
+		apiKey = apiKeyFlag
+	}
+
+	if timeout <= 0 {
+		timeout = cfg.Timeout
+	}
+	timeout, _ = resolveTimeouts(timeout, opts.serverTimeout)
+
+	headers, err := requestHeaders(headerFlags)
+	if err != nil {
+		output.Error(api.NewError(api.CodeInvalidInput, err))
+	}
+	opts.headers = headers
+	opts.applyConfigDefaults(cmd)
+
+	client := api.NewClient(apiBase, cfg.SearchAPIURL, apiKey, timeout)
+	configureClient(cmd, client, maxRetries, retryDelay)
+	return client
+}
+
+// responseFormatOf 返回响应格式，命令行参数优先
+func responseFormatOf(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	return cfg.DefaultResponseFormat
+}
+
+// buildReadRequest 根据读取选项构建 Read 请求
+func buildReadRequest(url, responseFormat string, opts *readOptions) *api.ReadRequest {
+	_, serverTimeout := resolveTimeouts(0, opts.serverTimeout)
+
+	// 内容裁剪选项：命令行参数优先，其次配置文件
+	removeSelectors := opts.removeSelectors
+	if len(removeSelectors) == 0 && cfg.RemoveSelector != "" {
+		removeSelectors = []string{cfg.RemoveSelector}
+	}
+	retainImages := cfg.RetainImages
+	if opts.retainImages != "" {
+		retainImages = opts.retainImages
+	}
+
+	return &api.ReadRequest{
+		URL:               url,
+		Method:            "GET",
+		ResponseFormat:    responseFormat,
+		Headers:           opts.headers,
+		Timeout:           serverTimeout,
+		CacheTolerance:    cfg.CacheTolerance,
+		TokenBudget:       tokenBudget(opts.tokenBudget),
+		WithGeneratedAlt:  opts.withAlt,
+		WithLinksSummary:  opts.linksSummary,
+		WithImagesSummary: opts.imagesSummary,
+		NoCache:           opts.noCache,
+		ProxyURL:          opts.proxy,
+		TargetSelector:    opts.targetSelector,
+		WaitForSelector:   opts.waitForSelector,
+		RemoveSelectors:   removeSelectors,
+		RetainImages:      retainImages,
+		WithIframe:        opts.withIframe,
+		WithShadowDOM:     opts.withShadowDOM,
+		Cookie:            opts.cookie,
+		Engine:            opts.engine,
+		Locale:            opts.locale,
+		UserAgent:         opts.userAgent,
+		Referer:           opts.referer,
+		PostMethod:        opts.post,
+		JSONResponse:      true,
+	}
+}
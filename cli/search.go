@@ -1,9 +1,17 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
 
 	"github.com/geekjourneyx/jina-cli/cli/pkg/api"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/httpheader"
 	"github.com/geekjourneyx/jina-cli/cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -13,10 +21,19 @@ var SearchCmd = &cobra.Command{
 	Use:     "search",
 	Aliases: []string{"s"},
 	Short:   "Search the web with AI-powered results",
-	Long:    `Search the web and return results in LLM-friendly format. Automatically fetches content from top 5 results.`,
+	Long: `Search the web and return results in LLM-friendly format. Automatically fetches content from top 5 results.
+
+Several queries (repeated --query or --queries-file) run concurrently and their results
+are merged with reciprocal rank fusion, deduplicated by normalized URL.`,
 	Example: `  jina search --query "golang latest news"
   jina search -q "AI developments" --site techcrunch.com --site theverge.com
-  jina search -q "climate change" --limit 10 --output markdown`,
+  jina search -q "climate change" --limit 10 --output markdown
+  jina search -q "golang generics" --page 2 --gl us --hl en
+  jina search -q "rust async runtime" --limit 10 --all-pages 3
+  jina search -q "sqlite wal mode" --no-content --limit 20 --output markdown
+  jina search -q "go 1.22 release notes" --read-results --read-top 3 --target-selector article
+  jina search -q "go arena allocator" -q "golang arena experiment" -q "go memory arenas proposal"
+  jina search --queries-file queries.txt --no-content`,
 	Args: cobra.NoArgs,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		return validateSearchFlags()
@@ -25,26 +42,82 @@ var SearchCmd = &cobra.Command{
 }
 
 var (
-	flagSearchQuery      string
-	flagSearchSites      []string
-	flagSearchFormat     string
-	flagSearchTimeout    int
-	flagSearchLimit      int
-	flagSearchOutputFile string
+	flagSearchQueries        []string
+	flagSearchQueriesFile    string
+	flagSearchSites          []string
+	flagSearchFormat         string
+	flagSearchTimeout        int
+	flagSearchLimit          int
+	flagSearchOutputFile     string
+	flagSearchMaxRetries     int
+	flagSearchRetryDelay     int
+	flagSearchServerTimeout  int
+	flagSearchCacheTolerance int
+	flagSearchTokenBudget    int
+	flagSearchHeaders        []string
+	flagSearchPage           int
+	flagSearchAllPages       int
+	flagSearchCountry        string
+	flagSearchLanguage       string
+	flagSearchNoContent      bool
+	flagSearchReadResults    bool
+	flagSearchReadTop        int
+	flagSearchConcurrency    int
+	flagSearchReadOptions    readOptions // --read-results 读取结果页面的选项，--no-cache 同时用于搜索请求
 )
 
 func init() {
-	SearchCmd.Flags().StringVarP(&flagSearchQuery, "query", "q", "", "Search query (required)")
+	SearchCmd.Flags().StringArrayVarP(&flagSearchQueries, "query", "q", nil, "Search query (repeatable; several queries are merged with rank fusion)")
+	SearchCmd.Flags().StringVar(&flagSearchQueriesFile, "queries-file", "", "File with one query per line ('-' for stdin, '#' starts a comment)")
 	SearchCmd.Flags().StringSliceVarP(&flagSearchSites, "site", "s", []string{}, "Restrict to specific domains (repeatable)")
 	SearchCmd.Flags().StringVarP(&flagSearchFormat, "format", "F", "", "Response format: markdown, html, text (default: markdown)")
 	SearchCmd.Flags().IntVarP(&flagSearchTimeout, "timeout", "t", 0, "Request timeout in seconds")
+	SearchCmd.Flags().IntVar(&flagSearchServerTimeout, "server-timeout", 0, "Max seconds the Search API spends fetching results (X-Timeout, default: config server_timeout)")
+	SearchCmd.Flags().IntVar(&flagSearchCacheTolerance, "cache-tolerance", 0, "Accept cached content up to this many seconds old (X-Cache-Tolerance, default: config cache_tolerance)")
+	SearchCmd.Flags().IntVar(&flagSearchTokenBudget, "token-budget", 0, "Fail requests that would use more than this many tokens (X-Token-Budget, default: config token_budget)")
 	SearchCmd.Flags().IntVarP(&flagSearchLimit, "limit", "l", 0, "Max results to return (default: 5)")
+	SearchCmd.Flags().IntVar(&flagSearchPage, "page", 0, "Result page to fetch, starting at 1")
+	SearchCmd.Flags().IntVar(&flagSearchAllPages, "all-pages", 0, "Fetch N consecutive pages and merge the results, deduplicated by URL")
+	SearchCmd.Flags().StringVar(&flagSearchCountry, "gl", "", "Country code to search from, e.g. us, de")
+	SearchCmd.Flags().StringVar(&flagSearchLanguage, "hl", "", "Interface language of the search, e.g. en, zh-cn")
 	SearchCmd.Flags().StringVarP(&flagSearchOutputFile, "output-file", "O", "", "Write output to file instead of stdout")
+	SearchCmd.Flags().IntVar(&flagSearchMaxRetries, "max-retries", 0, "Max retries for transient failures (default: config max_retries)")
+	SearchCmd.Flags().IntVar(&flagSearchRetryDelay, "retry-delay", 0, "Initial retry delay in milliseconds (default: config retry_delay)")
+	SearchCmd.Flags().BoolVar(&flagSearchNoContent, "no-content", false, "Return only title, url and description without fetching result pages (faster, fewer tokens)")
+	SearchCmd.Flags().BoolVar(&flagSearchReadResults, "read-results", false, "Read each result URL like 'jina read' and merge the full content into the result")
+	SearchCmd.Flags().IntVar(&flagSearchReadTop, "read-top", 0, "With --read-results, read only the top N results (default: all)")
+
+	SearchCmd.Flags().IntVarP(&flagSearchConcurrency, "concurrency", "c", 0, "Number of queries or results to process in parallel (default: config concurrency)")
+	SearchCmd.Flags().StringArrayVarP(&flagSearchHeaders, "header", "H", nil, "Extra request header 'Name: value' (repeatable, added to config [headers])")
+	addReadFlags(SearchCmd, &flagSearchReadOptions)
 }
 
 func validateSearchFlags() error {
-	if flagSearchQuery == "" {
-		return fmt.Errorf("必须提供 --query 参数")
+	if len(flagSearchQueries) == 0 && flagSearchQueriesFile == "" {
+		return fmt.Errorf("必须提供 --query 或 --queries-file 参数")
+	}
+	if flagSearchMaxRetries < 0 || flagSearchRetryDelay < 0 {
+		return fmt.Errorf("--max-retries 和 --retry-delay 不能为负数")
+	}
+	for _, h := range flagSearchHeaders {
+		if _, _, err := httpheader.Parse(h); err != nil {
+			return err
+		}
+	}
+	if flagSearchReadTop < 0 || flagSearchConcurrency < 0 {
+		return fmt.Errorf("--read-top 和 --concurrency 不能为负数")
+	}
+	if err := flagSearchReadOptions.validate(); err != nil {
+		return err
+	}
+	if flagSearchReadTop > 0 && !flagSearchReadResults {
+		return fmt.Errorf("--read-top 需要同时指定 --read-results")
+	}
+	if flagSearchPage < 0 || flagSearchAllPages < 0 {
+		return fmt.Errorf("--page 和 --all-pages 不能为负数")
+	}
+	if flagSearchServerTimeout < 0 || flagSearchCacheTolerance < 0 || flagSearchTokenBudget < 0 {
+		return fmt.Errorf("--server-timeout、--cache-tolerance 和 --token-budget 不能为负数")
 	}
 	return nil
 }
@@ -71,6 +144,19 @@ func runSearch(cmd *cobra.Command, args []string) {
 	if flagSearchTimeout > 0 {
 		timeout = flagSearchTimeout
 	}
+	timeout, serverTimeout := resolveTimeouts(timeout, flagSearchServerTimeout)
+	applyCacheTolerance(cmd, flagSearchCacheTolerance)
+
+	// 合并默认请求头和 --header 参数
+	headers, err := requestHeaders(flagSearchHeaders)
+	if err != nil {
+		output.Error(api.NewError(api.CodeInvalidInput, err))
+	}
+	flagSearchReadOptions.headers = headers
+	// --server-timeout 和 --token-budget 同时用于读取结果页面
+	flagSearchReadOptions.serverTimeout = flagSearchServerTimeout
+	flagSearchReadOptions.tokenBudget = flagSearchTokenBudget
+	flagSearchReadOptions.applyConfigDefaults(cmd)
 
 	// 获取响应格式
 	responseFormat := cfg.DefaultResponseFormat
@@ -86,56 +172,311 @@ func runSearch(cmd *cobra.Command, args []string) {
 
 	// 创建 API 客户端
 	client := api.NewClient(cfg.ReadAPIURL, searchAPIURL, apiKey, timeout)
+	configureClient(cmd, client, flagSearchMaxRetries, flagSearchRetryDelay)
 
 	// 获取输出处理器
 	out, err := output.GetOutput(output.OutputFormat(outputFormat), flagSearchOutputFile)
 	if err != nil {
-		output.Error(err)
+		output.Error(api.NewError(api.CodeInternal, err))
+	}
+	defer closeOutput(out)
+
+	queries, err := searchQueries()
+	if err != nil {
+		_ = out.Error(api.NewError(api.CodeInvalidInput, err))
+		return
 	}
-	defer func() {
-		if closer, ok := out.(interface{ Close() error }); ok {
-			closer.Close()
-		}
-	}()
 
 	// 构建请求
 	req := &api.SearchRequest{
-		Query:          flagSearchQuery,
+		Query:          queries[0],
 		Sites:          flagSearchSites,
 		ResponseFormat: responseFormat,
-		Timeout:        timeout,
+		Headers:        headers,
+		Timeout:        serverTimeout,
+		CacheTolerance: cfg.CacheTolerance,
+		TokenBudget:    tokenBudget(flagSearchTokenBudget),
 		Limit:          limit,
+		Page:           flagSearchPage,
+		Country:        flagSearchCountry,
+		Language:       flagSearchLanguage,
+		NoCache:        flagSearchReadOptions.noCache,
+		// 读取全部结果页面时，搜索本身无需抓取内容；--read-top 之外的结果保留搜索返回的内容
+		NoContent: flagSearchNoContent || (flagSearchReadResults && flagSearchReadTop == 0),
 	}
 
-	// 执行搜索
-	resp, err := client.Search(req)
-	if err != nil {
-		_ = out.Error(err)
-		return
+	var results []map[string]interface{}
+	var summary map[string]interface{}
+	var totalTokens int
+	if len(queries) == 1 {
+		resp, err := searchPages(cmd.Context(), client, req)
+		if err != nil {
+			_ = out.Error(err)
+			return
+		}
+		for _, result := range resp.Results {
+			results = append(results, searchResultToMap(result, !req.NoContent))
+		}
+		summary = searchSummary(resp)
+		totalTokens = resp.Usage.Tokens
+	} else {
+		results, summary, totalTokens, err = searchMulti(cmd.Context(), client, req, queries)
+		if err != nil {
+			_ = out.Error(err)
+			return
+		}
+	}
+
+	// 读取结果页面并合并完整内容
+	var stats readStats
+	if flagSearchReadResults {
+		stats = readSearchResults(cmd.Context(), client, results, flagSearchReadTop, responseFormat, &flagSearchReadOptions)
+		summary["read"] = map[string]interface{}{
+			"total":     stats.total,
+			"succeeded": stats.succeeded,
+			"failed":    stats.failed,
+		}
+		if tokens := totalTokens + stats.tokens; tokens > 0 {
+			summary["usage"] = map[string]interface{}{"tokens": tokens}
+		}
 	}
 
-	// 构建输出数据
-	results := make([]map[string]interface{}, 0, len(resp.Results))
-	for _, result := range resp.Results {
-		r := map[string]interface{}{
-			"content": result.Content,
+	// 流式输出：逐条输出结果，最后输出汇总
+	if stream, ok := out.(output.StreamOutput); ok {
+		for i, result := range results {
+			_ = stream.PrintItem(i, result)
 		}
-		if result.Title != "" {
-			r["title"] = result.Title
+		_ = stream.PrintSummary(summary)
+	} else {
+		summary["results"] = results
+		out.Print(summary)
+	}
+
+	if cmd.Context().Err() != nil {
+		exitInterrupted(out, stats.succeeded+stats.failed, stats.total)
+	}
+}
+
+// searchQueries 合并 --query 和 --queries-file 中的查询，去除空行、注释和重复项
+func searchQueries() ([]string, error) {
+	queries := append([]string{}, flagSearchQueries...)
+
+	if flagSearchQueriesFile != "" {
+		var content []byte
+		var err error
+		if flagSearchQueriesFile == "-" {
+			content, err = io.ReadAll(os.Stdin)
+		} else {
+			content, err = os.ReadFile(flagSearchQueriesFile)
 		}
-		if result.URL != "" {
-			r["url"] = result.URL
+		if err != nil {
+			return nil, fmt.Errorf("读取查询文件失败: %w", err)
 		}
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line != "" && !strings.HasPrefix(line, "#") {
+				queries = append(queries, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("读取查询文件失败: %w", err)
+		}
+	}
+
+	seen := make(map[string]bool, len(queries))
+	unique := queries[:0]
+	for _, q := range queries {
+		q = strings.TrimSpace(q)
+		if q == "" || seen[q] {
+			continue
+		}
+		seen[q] = true
+		unique = append(unique, q)
+	}
+	if len(unique) == 0 {
+		return nil, fmt.Errorf("没有找到有效的查询")
+	}
+	return unique, nil
+}
+
+// searchPages 执行单个查询，--all-pages 时连续获取多页并按 URL 去重
+func searchPages(ctx context.Context, client *api.Client, req *api.SearchRequest) (*api.SearchResponse, error) {
+	if flagSearchAllPages > 1 {
+		return client.SearchPagesContext(ctx, req, flagSearchAllPages)
+	}
+	return client.SearchContext(ctx, req)
+}
+
+// searchMulti 并发执行多个查询，用倒数排名融合合并结果
+//
+// 每个结果带有融合得分 score 和命中的查询 queries。部分查询失败时记录在汇总的
+// failed_queries 字段中，全部失败时返回第一个错误。
+func searchMulti(ctx context.Context, client *api.Client, base *api.SearchRequest, queries []string) ([]map[string]interface{}, map[string]interface{}, int, error) {
+	concurrency := batchConcurrency(flagSearchConcurrency)
+
+	responses := make([]*api.SearchResponse, len(queries))
+	errs := make([]error, len(queries))
+	forEachConcurrent(ctx, len(queries), concurrency, func(i int) {
+		req := *base
+		req.Query = queries[i]
+		responses[i], errs[i] = searchPages(ctx, client, &req)
+	})
+
+	var failed []map[string]interface{}
+	var firstErr error
+	tokens := 0
+	for i, resp := range responses {
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			failed = append(failed, map[string]interface{}{
+				"query": queries[i],
+				"error": errs[i].Error(),
+				"code":  api.ErrorCodeOf(errs[i]),
+			})
+			continue
+		}
+		if resp != nil {
+			tokens += resp.Usage.Tokens
+		}
+	}
+	if len(failed) == len(queries) {
+		return nil, nil, 0, firstErr
+	}
+
+	fused := api.FuseSearchResults(responses)
+	results := make([]map[string]interface{}, 0, len(fused))
+	for _, f := range fused {
+		r := searchResultToMap(f.SearchResult, !base.NoContent)
+		r["score"] = f.Score
+		r["queries"] = f.Queries
 		results = append(results, r)
 	}
 
-	outputData := map[string]interface{}{
-		"query":   resp.Query,
-		"results": results,
+	summary := map[string]interface{}{
+		"queries": queries,
 		"count":   len(results),
 	}
+	if len(failed) > 0 {
+		summary["failed_queries"] = failed
+	}
+	if tokens > 0 {
+		summary["usage"] = map[string]interface{}{"tokens": tokens}
+	}
+	return results, summary, tokens, nil
+}
+
+// readStats 读取搜索结果页面的统计
+type readStats struct {
+	total, succeeded, failed, tokens int
+}
+
+// readSearchResults 并发读取前 top 个搜索结果（0 表示全部），将完整内容合并到结果中
+//
+// 每个 URL 按 opts 构建请求并读取，结果保持搜索排名顺序并带有 rank 字段。
+// 读取失败或结果没有 URL 时在该结果中记录 read_error 和 read_code，不影响其他结果。
+func readSearchResults(ctx context.Context, client *api.Client, results []map[string]interface{}, top int, responseFormat string, opts *readOptions) readStats {
+	for i, result := range results {
+		result["rank"] = i + 1
+	}
+
+	stats := readStats{total: len(results)}
+	if top > 0 && top < stats.total {
+		stats.total = top
+	}
+
+	concurrency := batchConcurrency(flagSearchConcurrency)
+
+	var mu sync.Mutex
+	forEachConcurrent(ctx, stats.total, concurrency, func(i int) {
+		var read map[string]interface{}
+		if url, _ := results[i]["url"].(string); url == "" {
+			read = map[string]interface{}{
+				"error": "搜索结果没有 URL",
+				"code":  api.CodeInvalidInput,
+			}
+		} else {
+			read = readBatchURL(ctx, client, buildReadRequest(url, responseFormat, opts))
+			if read == nil {
+				return
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if mergeReadResult(results[i], read) {
+			stats.succeeded++
+		} else {
+			stats.failed++
+		}
+		if usage, ok := read["usage"].(map[string]interface{}); ok {
+			tokens, _ := usage["tokens"].(int)
+			stats.tokens += tokens
+		}
+	})
+	return stats
+}
+
+// mergeReadResult 将读取结果合并到搜索结果中，读取失败时返回 false
+//
+// 搜索结果的标题、URL 和描述保持不变，内容替换为完整页面内容。
+func mergeReadResult(result, read map[string]interface{}) bool {
+	if errMsg, failed := read["error"]; failed {
+		result["read_error"] = errMsg
+		result["read_code"] = read["code"]
+		return false
+	}
+	for _, key := range []string{"content", "final_url", "published_time", "images", "links", "warning", "usage"} {
+		if v, ok := read[key]; ok {
+			result[key] = v
+		}
+	}
+	if _, ok := result["title"]; !ok {
+		if title, ok := read["title"]; ok {
+			result["title"] = title
+		}
+	}
+	return true
+}
 
-	out.Print(outputData)
+// searchSummary 构建搜索结果的汇总信息
+func searchSummary(resp *api.SearchResponse) map[string]interface{} {
+	summary := map[string]interface{}{
+		"query": resp.Query,
+		"count": len(resp.Results),
+	}
+	if resp.Usage.Tokens > 0 {
+		summary["usage"] = map[string]interface{}{"tokens": resp.Usage.Tokens}
+	}
+	return summary
+}
+
+// searchResultToMap 将搜索结果转换为输出数据，空字段不输出
+//
+// withContent 为 false（--no-content）时不输出 content 字段，Markdown 输出据此显示紧凑列表。
+func searchResultToMap(result api.SearchResult, withContent bool) map[string]interface{} {
+	r := map[string]interface{}{}
+	if withContent {
+		r["content"] = result.Content
+	}
+	if result.Title != "" {
+		r["title"] = result.Title
+	}
+	if result.URL != "" {
+		r["url"] = result.URL
+	}
+	if result.Description != "" {
+		r["description"] = result.Description
+	}
+	if result.Date != "" {
+		r["date"] = result.Date
+	}
+	if result.Usage.Tokens > 0 {
+		r["usage"] = map[string]interface{}{"tokens": result.Usage.Tokens}
+	}
+	return r
 }
 
 func getSearchOutputFormat(cmd *cobra.Command) string {
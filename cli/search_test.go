@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/geekjourneyx/jina-cli/cli/pkg/api"
+	"github.com/geekjourneyx/jina-cli/cli/pkg/config"
+)
+
+func TestReadSearchResults(t *testing.T) {
+	reader := &fakeReader{}
+	server := httptest.NewServer(reader)
+	defer server.Close()
+
+	client := api.NewClient(server.URL, server.URL, "", 5)
+	client.SetRetryPolicy(api.RetryPolicy{})
+
+	oldCfg, oldConcurrency := cfg, flagSearchConcurrency
+	t.Cleanup(func() { cfg, flagSearchConcurrency = oldCfg, oldConcurrency })
+	cfg = &config.Config{Concurrency: 2}
+	flagSearchConcurrency = 0
+
+	newResults := func() []map[string]interface{} {
+		return []map[string]interface{}{
+			{"title": "A", "url": "https://example.com/a", "content": "snippet a"},
+			{"title": "Fail", "url": "https://example.com/fail"},
+			{"title": "No URL"},
+			{"title": "B", "url": "https://example.com/b", "content": "snippet b"},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		top          int
+		wantRequests []string
+		want         readStats
+		wantContent  []string // 每个结果的 content，空字符串表示没有 content
+		wantReadCode []string // 每个结果的 read_code，空字符串表示读取成功或未读取
+	}{
+		{
+			name:         "all",
+			top:          0,
+			wantRequests: []string{"https://example.com/a", "https://example.com/fail", "https://example.com/b"},
+			want:         readStats{total: 4, succeeded: 2, failed: 2, tokens: 20},
+			wantContent:  []string{"body of https://example.com/a", "", "", "body of https://example.com/b"},
+			wantReadCode: []string{"", api.CodeTargetUnreachable, api.CodeInvalidInput, ""},
+		},
+		{
+			name:         "read top",
+			top:          2,
+			wantRequests: []string{"https://example.com/a", "https://example.com/fail"},
+			want:         readStats{total: 2, succeeded: 1, failed: 1, tokens: 10},
+			wantContent:  []string{"body of https://example.com/a", "", "", "snippet b"},
+			wantReadCode: []string{"", api.CodeTargetUnreachable, "", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := newResults()
+			stats := readSearchResults(context.Background(), client, results, tt.top, "markdown", &readOptions{})
+
+			if stats != tt.want {
+				t.Errorf("stats = %+v, want %+v", stats, tt.want)
+			}
+			requests := reader.takeRequests()
+			slices.Sort(requests)
+			want := slices.Clone(tt.wantRequests)
+			slices.Sort(want)
+			if !slices.Equal(requests, want) {
+				t.Errorf("requests = %v, want %v", requests, want)
+			}
+
+			for i, result := range results {
+				if result["rank"] != i+1 {
+					t.Errorf("result %d rank = %v, want %d", i, result["rank"], i+1)
+				}
+				content, _ := result["content"].(string)
+				if content != tt.wantContent[i] {
+					t.Errorf("result %d content = %q, want %q", i, content, tt.wantContent[i])
+				}
+				code, _ := result["read_code"].(string)
+				if code != tt.wantReadCode[i] {
+					t.Errorf("result %d read_code = %q, want %q", i, code, tt.wantReadCode[i])
+				}
+				if _, failed := result["read_error"]; failed != (code != "") {
+					t.Errorf("result %d read_error present = %t, want %t", i, failed, code != "")
+				}
+			}
+		})
+	}
+}
+
+func TestMergeReadResult(t *testing.T) {
+	result := map[string]interface{}{"title": "Search title", "url": "https://example.com/a", "content": "snippet", "rank": 1}
+	ok := mergeReadResult(result, map[string]interface{}{
+		"title":     "Page title",
+		"url":       "https://example.com/a",
+		"final_url": "https://example.com/a/",
+		"content":   "full page",
+		"usage":     map[string]interface{}{"tokens": 42},
+	})
+	if !ok {
+		t.Fatal("mergeReadResult() = false, want true")
+	}
+	want := map[string]interface{}{"title": "Search title", "content": "full page", "final_url": "https://example.com/a/", "rank": 1}
+	for k, v := range want {
+		if result[k] != v {
+			t.Errorf("result[%s] = %v, want %v", k, result[k], v)
+		}
+	}
+
+	failed := map[string]interface{}{"url": "https://example.com/b", "content": "snippet"}
+	if mergeReadResult(failed, map[string]interface{}{"error": "HTTP 错误: 422", "code": api.CodeTargetUnreachable}) {
+		t.Fatal("mergeReadResult() = true for failed read")
+	}
+	if failed["read_error"] != "HTTP 错误: 422" || failed["read_code"] != api.CodeTargetUnreachable || failed["content"] != "snippet" {
+		t.Errorf("failed result = %v", failed)
+	}
+}